@@ -15,13 +15,36 @@ import numpy as np
 if 'myStyle' in plt.style.available:
   style.use('myStyle')
 
-def visualizeResult(path: str, *otherFiles: str):
+def loadResults(file, algorithm=None):
+  '''
+  Loads the result file and returns a list with one result per algorithm. A result file can contain the
+  results of several algorithms, in this case every result of the list has the key 'Algorithm' and the
+  'AccuracyResults' of this algorithm like a result file with only one algorithm. If an algorithm is
+  specified, only the result of this algorithm is returned.
+  '''
+  f = open(file)
+  jsonData = json.load(f)
+  f.close()
+  if 'Algorithms' not in jsonData:
+    results = [jsonData]
+  else:
+    results = []
+    for i, name in enumerate(jsonData['Algorithms']):
+      result = {key: value for key, value in jsonData.items() if key not in ['Algorithms', 'AccuracyResults']}
+      result['Algorithm'] = name
+      result['AccuracyResults'] = [ar['Algorithms'][i] for ar in jsonData['AccuracyResults']]
+      results.append(result)
+  if algorithm != None:
+    results = [result for result in results if result['Algorithm'] == algorithm]
+  return results
+
+def visualizeResult(path: str, *otherFiles: str, algorithm=None):
   '''
   This function visualizes results as box plots. It takes the path to the json file which contains the results.
   Optionally, other files can be specified. The function will try to merge the optional files in the results
-  of the main file. 
+  of the main file. If the files contain results of several algorithms, the algorithm has to be specified.
   '''
-  data, stddevValues, algorithm, pointsPerPlane, iterations = mergeResults(path, *otherFiles)
+  data, stddevValues, algorithm, pointsPerPlane, iterations = mergeResults(path, *otherFiles, algorithm=algorithm)
 
   # get the right scaling on x-axis
   differences = np.array(stddevValues[1:]) - np.array(stddevValues[:-1])
@@ -41,7 +64,7 @@ def visualizeResult(path: str, *otherFiles: str):
 
   plt.show()
 
-def mergeResults(mainFile, *otherFiles):
+def mergeResults(mainFile, *otherFiles, algorithm=None):
   '''
   Takes a list of paths to files and tries to merge their results together. If the results came from
   different algorithms, this function will raise an exception. It returns 5 values.
@@ -61,9 +84,7 @@ def mergeResults(mainFile, *otherFiles):
   '''
   jsonDataList = []
   for file in [mainFile, *otherFiles]:
-    f = open(file)
-    jsonDataList.append(json.load(f))
-    f.close()
+    jsonDataList.extend(loadResults(file, algorithm))
 
   algorithm = set([jsonData['Algorithm'] for jsonData in jsonDataList])
   if len(algorithm) > 1:
@@ -104,10 +125,8 @@ def visualizeTime(*files: str):
   '''
   jsonData = []
   times = []
-  for file in files:
-    f = open(file)
-    jsonData.append(json.load(f))
-    f.close()
+  for result in [result for file in files for result in loadResults(file)]:
+    jsonData.append(result)
 
     timeArray = np.empty(len(jsonData[-1]['AccuracyResults']))
     for i, ar in enumerate(jsonData[-1]['AccuracyResults']):
@@ -120,7 +139,7 @@ def visualizeTime(*files: str):
   for i, timeArray in enumerate(times):
     title = jsonData[i]['Algorithm'] + ': '
     title += f'Datapoints: {jsonData[i]["PointsPerPlane"]*3}, Iterations: {jsonData[i]["Iterations"]}'
-    if jsonData[i]['Cores'] != None:
+    if jsonData[i].get('Cores') != None:
       title += ', CPU-Cores: ' + str(jsonData[i]['Cores'])
     ax.plot(jsonData[i]['StddevValues'], timeArray, label=title)
 
@@ -140,10 +159,8 @@ def visualizeAverages(*files: str):
   '''
   jsonData = []
   times = []
-  for file in files:
-    f = open(file)
-    jsonData.append(json.load(f))
-    f.close()
+  for result in [result for file in files for result in loadResults(file)]:
+    jsonData.append(result)
 
     accArray = np.empty((len(jsonData[-1]['AccuracyResults']), len(jsonData[-1]['AccuracyResults'][0]['Accuracies'])))
     for i, ar in enumerate(jsonData[-1]['AccuracyResults']):
@@ -176,6 +193,7 @@ if __name__ == '__main__':
   parser.add_argument('-d', '--directory', help='The directory of the result file')
   parser.add_argument('-t', '--type', default='box', help='What should be visualized: box - The results as box plot, time - The execution time')
   parser.add_argument('-f', '--files', default=[], nargs='*', help='Further files that can be visualized for execution time')
+  parser.add_argument('-a', '--algorithm', help='Which algorithm of a result file with several algorithms is shown as box plot')
   args = parser.parse_args()
 
   directory = args.directory if args.directory != None and args.directory != '' else './../temp/results'
@@ -186,7 +204,7 @@ if __name__ == '__main__':
     furtherFiles.append(path.abspath(path.join(path.dirname(__file__), directory, file)))
   
   if args.type.lower() == 'box':
-    visualizeResult(fullPath, *furtherFiles)
+    visualizeResult(fullPath, *furtherFiles, algorithm=args.algorithm)
   elif args.type.lower() == 'time':
     visualizeTime(fullPath, *furtherFiles)
   elif args.type.lower() == 'accuracy':
@@ -0,0 +1,121 @@
+package algorithm
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/utils"
+)
+
+// A cost calculator which wraps another cost calculator and memoizes the triple costs it
+// computes. The costs are stored by the indices of the three elements in the input, so the
+// order of the elements doesn't matter. The cache is bounded, if it's full the least recently
+// used cost is discarded. Only elements of the input the cache was created for are cached,
+// the costs of all other elements are just passed to the wrapped calculator.
+type CachedCostCalculator[data any] struct {
+	calc     CostCalculator[data]
+	indices  map[*data]int
+	capacity int
+	entries  map[[3]int]*list.Element
+	usage    *list.List // The cached triples, the most recently used triple is at the front
+	stats    CacheStatistics
+	mutex    sync.Mutex
+}
+
+// How often a cached cost calculator could answer a request from its cache
+type CacheStatistics struct {
+	Hits   int
+	Misses int
+}
+
+type cacheEntry struct {
+	key  [3]int
+	cost float64
+}
+
+// Returns the ratio of requests that were answered from the cache
+func (stats CacheStatistics) HitRate() float64 {
+	if stats.Hits+stats.Misses == 0 {
+		return 0
+	}
+	return float64(stats.Hits) / float64(stats.Hits+stats.Misses)
+}
+
+// Creates a cost calculator that caches at most `capacity` triple costs of the elements in the
+// given input. The returned calculator must only be used with this input.
+func CreateCachedCostCalculator[data any](input *[]data, calc CostCalculator[data], capacity int) *CachedCostCalculator[data] {
+	if capacity < 1 {
+		panic("The capacity of the cache must be positive")
+	}
+	indices := make(map[*data]int, len(*input))
+	for i := range *input {
+		indices[&(*input)[i]] = i
+	}
+	return &CachedCostCalculator[data]{
+		calc:     calc,
+		indices:  indices,
+		capacity: capacity,
+		entries:  make(map[[3]int]*list.Element),
+		usage:    list.New(),
+	}
+}
+
+// Returns the triple cost of the wrapped calculator, if possible out of the cache
+func (cache *CachedCostCalculator[data]) TripleCost(d1, d2, d3 *data) float64 {
+	i, ok1 := cache.indices[d1]
+	j, ok2 := cache.indices[d2]
+	k, ok3 := cache.indices[d3]
+	if !ok1 || !ok2 || !ok3 {
+		return cache.calc.TripleCost(d1, d2, d3)
+	}
+	utils.SortInts(&i, &j, &k)
+	key := [3]int{i, j, k}
+
+	cache.mutex.Lock()
+	if element, ok := cache.entries[key]; ok {
+		cache.stats.Hits++
+		cache.usage.MoveToFront(element)
+		cost := element.Value.(*cacheEntry).cost
+		cache.mutex.Unlock()
+		return cost
+	}
+	cache.stats.Misses++
+	cache.mutex.Unlock()
+
+	cost := cache.calc.TripleCost(d1, d2, d3)
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	if _, ok := cache.entries[key]; ok {
+		// another goroutine computed the same cost in the meantime
+		return cost
+	}
+	if cache.usage.Len() >= cache.capacity {
+		oldest := cache.usage.Back()
+		cache.usage.Remove(oldest)
+		delete(cache.entries, oldest.Value.(*cacheEntry).key)
+	}
+	cache.entries[key] = cache.usage.PushFront(&cacheEntry{key: key, cost: cost})
+	return cost
+}
+
+// Returns the number of cache hits and misses so far
+func (cache *CachedCostCalculator[data]) Statistics() CacheStatistics {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	return cache.stats
+}
+
+// Returns the given algorithm which uses a cache with the given capacity for its cost calculator.
+// The statistics of the cache are passed to the report function after the partitioning, if it's
+// not nil.
+func WithCache[data any](algorithm PartitioningAlgorithm[data], capacity int, report func(CacheStatistics)) PartitioningAlgorithm[data] {
+	return func(input *[]data, calc CostCalculator[data]) PartitioningArray {
+		cache := CreateCachedCostCalculator(input, calc, capacity)
+		partitioning := algorithm(input, cache)
+		if report != nil {
+			report(cache.Statistics())
+		}
+		return partitioning
+	}
+}
@@ -0,0 +1,59 @@
+package algorithm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Counts how often the triple cost was computed
+type CountingCostCalc struct {
+	CharCostCalc
+	calls int
+}
+
+func (calc *CountingCostCalc) TripleCost(c1, c2, c3 *string) float64 {
+	calc.calls++
+	return calc.CharCostCalc.TripleCost(c1, c2, c3)
+}
+
+func TestCachedCostCalculator(t *testing.T) {
+	dataPoints := []string{"b", "c", "hello", "but", "howdy"}
+	calc := &CountingCostCalc{}
+	cache := CreateCachedCostCalculator[string](&dataPoints, calc, 2)
+
+	assert.Equal(t, 1.0, cache.TripleCost(&dataPoints[0], &dataPoints[1], &dataPoints[2]))
+	assert.Equal(t, 1.0, cache.TripleCost(&dataPoints[2], &dataPoints[0], &dataPoints[1]), "The order of the elements doesn't matter")
+	assert.Equal(t, 1, calc.calls)
+	assert.Equal(t, CacheStatistics{Hits: 1, Misses: 1}, cache.Statistics())
+
+	assert.Equal(t, -1.0, cache.TripleCost(&dataPoints[2], &dataPoints[4], &dataPoints[4]))
+	assert.Equal(t, 1.0, cache.TripleCost(&dataPoints[0], &dataPoints[1], &dataPoints[3]))
+	assert.Equal(t, 3, calc.calls)
+
+	// the first triple was the least recently used one, so it's not cached anymore
+	cache.TripleCost(&dataPoints[0], &dataPoints[1], &dataPoints[2])
+	assert.Equal(t, 4, calc.calls)
+	cache.TripleCost(&dataPoints[1], &dataPoints[3], &dataPoints[0])
+	assert.Equal(t, 4, calc.calls)
+	assert.Equal(t, CacheStatistics{Hits: 2, Misses: 4}, cache.Statistics())
+	assert.InDelta(t, 1.0/3.0, cache.Statistics().HitRate(), 0.00000001)
+
+	// elements that are not in the input are not cached
+	other := "bob"
+	assert.Equal(t, -1.0, cache.TripleCost(&other, &dataPoints[0], &dataPoints[3]))
+	assert.Equal(t, 5, calc.calls)
+	assert.Equal(t, CacheStatistics{Hits: 2, Misses: 4}, cache.Statistics())
+}
+
+func TestWithCache(t *testing.T) {
+	dataPoints := []string{"b", "c", "hello", "but", "howdy", "charley", "big", "delta", "brother", "humor"}
+	var stats CacheStatistics
+	partitioning := WithCache(NaiveGreedyJoining[string], 1000, func(s CacheStatistics) {
+		stats = s
+	})(&dataPoints, &CharCostCalc{})
+
+	assert.Equal(t, NaiveGreedyJoining[string](&dataPoints, &CharCostCalc{}), partitioning)
+	assert.Positive(t, stats.Hits)
+	assert.Equal(t, 120, stats.Misses, "Every triple should only be computed once")
+}
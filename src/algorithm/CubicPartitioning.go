@@ -27,9 +27,37 @@ func (array *PartitioningArray) InitializeSingletonSets(n int) {
 	}
 }
 
+// Computes the objective value of the given partitioning, which is the sum of the triple
+// costs of all triples whose elements are in the same partition. Noise isn't a partition.
+func Objective[data any](input *[]data, calc CostCalculator[data], partitioning PartitioningArray) float64 {
+	partitions := make(map[int][]int)
+	for element, partition := range partitioning {
+		if partition == NoiseLabel {
+			continue
+		}
+		partitions[partition] = append(partitions[partition], element)
+	}
+	objective := 0.0
+	for _, elements := range partitions {
+		for i := 0; i < len(elements)-2; i++ {
+			for j := i + 1; j < len(elements)-1; j++ {
+				for k := j + 1; k < len(elements); k++ {
+					objective += calc.TripleCost(&(*input)[elements[i]], &(*input)[elements[j]], &(*input)[elements[k]])
+				}
+			}
+		}
+	}
+	return objective
+}
+
 // This is the function signature which every partitioning algorithm should have
 type PartitioningAlgorithm[data any] func(input *[]data, calc CostCalculator[data]) PartitioningArray
 
+// A partitioning algorithm which is split into two phases, s.t. they can be measured separately.
+// Calling it initializes the algorithm for the input (e.g. the costs of the singleton sets),
+// calling the returned function performs the search and returns the partitioning.
+type PhasedAlgorithm[data any] func(input *[]data, calc CostCalculator[data]) func() PartitioningArray
+
 // This data structure can store whether two elements should be in different partitions.
 // The greedy moving algorithm can use it during it's execution to ensure that the
 // resulting partitioning satisfies the constraints.
@@ -56,3 +56,36 @@ func TestInitializeSingletonSets(t *testing.T) {
 		assert.Equal(t, i, v)
 	}
 }
+
+func TestPhasedAlgorithms(t *testing.T) {
+	dataPoints := []string{"b", "c", "hello", "but", "howdy", "bye", "car", "hi"}
+	for _, name := range []string{"GreedyJoining", "GreedyMoving", "NaiveGreedyJoining", "NaiveGreedyMoving", "SampledGreedy"} {
+		calc := &CountingCostCalc{}
+		search := AlgorithmStringToPhasedFunc[string](name)(&dataPoints, calc)
+		if name == "NaiveGreedyJoining" {
+			assert.Equal(t, 0, calc.calls, "The naive greedy joining computes all costs during the search")
+		} else {
+			assert.Greater(t, calc.calls, 0, "%s should compute costs during the initialization", name)
+		}
+		partitioning := search()
+		expected := AlgorithmStringToFunc[string](name)(&dataPoints, CharCostCalc{})
+		assert.True(t, samePartitions(expected, partitioning), "%s: %v and %v should be the same partitions", name, expected, partitioning)
+	}
+	assert.Panics(t, func() { AlgorithmStringToPhasedFunc[string]("") })
+	assert.Panics(t, func() { AlgorithmStringToPhasedFunc[string]("Unknown") })
+}
+
+// Returns whether the partitionings contain the same partitions, the ids of the partitions can differ
+func samePartitions(partitioning1, partitioning2 PartitioningArray) bool {
+	if len(partitioning1) != len(partitioning2) {
+		return false
+	}
+	for i := range partitioning1 {
+		for j := range partitioning1 {
+			if (partitioning1[i] == partitioning1[j]) != (partitioning2[i] == partitioning2[j]) {
+				return false
+			}
+		}
+	}
+	return true
+}
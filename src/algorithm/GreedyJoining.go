@@ -14,7 +14,9 @@ type GreedyJoiningAlgorithm[data any] struct {
 	input        *[]data
 	calc         CostCalculator[data]
 	partitioning PartitioningArray
+	partitions   []*utils.LinkedList[int] // The elements of every partition, indexed like the first dimension of the costs
 	costs        *Costs
+	bestJoins    *utils.TournamentTree // The minimum join cost of every partition in the first dimension
 }
 
 // A data structure which stores the costs that were calculated for the greedy joining
@@ -25,6 +27,7 @@ type OnePartitionCosts struct {
 	minCost            float64
 	bestJoin           int
 	twoPartitionsCosts []*TwoPartitionsCosts
+	joinCosts          *utils.TournamentTree // The join costs in the second dimension
 }
 
 type TwoPartitionsCosts struct {
@@ -101,6 +104,14 @@ func (costs *Costs) GetIndex(i, j int) int {
 func (costs *Costs) Delete2D(i, j int) {
 	index := costs.GetIndex(i, j)
 	(*(*costs)[i]).twoPartitionsCosts = append((*(*costs)[i]).twoPartitionsCosts[:index], (*(*costs)[i]).twoPartitionsCosts[index+1:]...)
+	(*costs)[i].joinCosts.Delete(index)
+}
+
+// Sets the join cost of partition i with the partition at the given index
+// in the second dimension of i
+func (costs *Costs) setJoinCost(i, index int, cost float64) {
+	(*costs)[i].twoPartitionsCosts[index].joinCost = cost
+	(*costs)[i].joinCosts.Set(index, cost)
 }
 
 // Deletes the costs for partition k in the third dimension for
@@ -126,28 +137,17 @@ func (costs *Costs) Delete3D(i, j, k int) {
 	} else if twoPartitionsCosts.bestJoin > index3D {
 		twoPartitionsCosts.bestJoin = twoPartitionsCosts.bestJoin - 1
 	}
+	(*costs)[i].joinCosts.Set(index2D, twoPartitionsCosts.joinCost)
 }
 
 // Calculates the sum over all triples cost where each element in the triple is in
 // either partition i, j or k (triples that are exclusive to this combination of partitions)
 func (algorithm *GreedyJoiningAlgorithm[data]) TripleCost3Part(i, j, k int) float64 {
 	cost := 0.0
-	iPartition := utils.LinkedList[int]{}
-	jPartition := utils.LinkedList[int]{}
-	kPartition := utils.LinkedList[int]{}
-
-	for index, partition := range algorithm.partitioning {
-		switch partition {
-		case i:
-			iPartition.Add(index)
-		case j:
-			jPartition.Add(index)
-		case k:
-			kPartition.Add(index)
-		}
-	}
+	jPartition := algorithm.partitions[j]
+	kPartition := algorithm.partitions[k]
 
-	iIter := iPartition.Iterator()
+	iIter := algorithm.partitions[i].Iterator()
 	for iIter.HasNext() {
 		iElement := iIter.Next()
 		jIter := jPartition.Iterator()
@@ -166,36 +166,75 @@ func (algorithm *GreedyJoiningAlgorithm[data]) TripleCost3Part(i, j, k int) floa
 	return cost
 }
 
-// Recompute the minimum over the second dimension of partition i.
-// It also checks if this cost is better than the cost stored in
-func (costs *Costs) Min2D(i int, bestJoinOverall *[2]int, bestJoinCostOverall *float64) {
-	// recompute the minimum over the second dimension of i
+// Recompute the minimum over the second dimension of partition i. The minimum
+// is looked up in the tournament tree of i, so this takes constant time.
+func (costs *Costs) Min2D(i int) {
 	onePartitionCosts := (*costs)[i]
-	min, argmin := utils.MinAndArgMin(utils.Map(onePartitionCosts.twoPartitionsCosts, func(element *TwoPartitionsCosts) float64 {
-		return element.joinCost
+	onePartitionCosts.minCost, onePartitionCosts.bestJoin = onePartitionCosts.joinCosts.Min()
+}
+
+// Recompute the minimum over the second dimension of partition i and store it
+// in the tournament tree over the first dimension
+func (algorithm *GreedyJoiningAlgorithm[data]) updateMin2D(i int) {
+	algorithm.costs.Min2D(i)
+	algorithm.bestJoins.Set(i, (*algorithm.costs)[i].minCost)
+}
+
+// Creates the tournament tree over the minimum join costs of all partitions
+// in the first dimension
+func (algorithm *GreedyJoiningAlgorithm[data]) initializeBestJoins() {
+	algorithm.bestJoins = utils.CreateTournamentTree(utils.Map(*algorithm.costs, func(element *OnePartitionCosts) float64 {
+		return element.minCost
 	}))
-	onePartitionCosts.bestJoin = argmin
-	onePartitionCosts.minCost = min
-
-	// if these costs are better than the previous best cost over the first dimension we store them
-	if min < *bestJoinCostOverall {
-		*bestJoinCostOverall = min
-		(*bestJoinOverall)[0] = i
-		(*bestJoinOverall)[1] = i + argmin + 1
+}
+
+// Returns the indices of the two partitions which have the best join cost as well as the cost.
+// If no join is possible the indices are -1.
+func (algorithm *GreedyJoiningAlgorithm[data]) bestJoin() ([2]int, float64) {
+	cost, i := algorithm.bestJoins.Min()
+	if i == -1 || math.IsInf(cost, 1) {
+		return [2]int{-1, -1}, math.Inf(1)
+	}
+	return [2]int{i, i + (*algorithm.costs)[i].bestJoin + 1}, cost
+}
+
+// Updates the member lists to the join of the two partitions part1 and part2. The elements
+// of part2 are appended to part1 in constant time.
+func (algorithm *GreedyJoiningAlgorithm[data]) updatePartitions(part1, part2 int) {
+	algorithm.partitions[part1].AddList(*algorithm.partitions[part2])
+	algorithm.partitions = append(algorithm.partitions[:part2], algorithm.partitions[part2+1:]...)
+}
+
+// Creates the member lists of the partitions out of the partitioning array
+func (algorithm *GreedyJoiningAlgorithm[data]) initializePartitions() {
+	algorithm.partitions = make([]*utils.LinkedList[int], len(*algorithm.costs)+1)
+	for i := range algorithm.partitions {
+		algorithm.partitions[i] = utils.CreateLinkedList[int]()
+	}
+	for element, partition := range algorithm.partitioning {
+		algorithm.partitions[partition].Add(element)
 	}
 }
 
-// Updates the partitioning array to the join of the two partitions part1 and part2
-func (algorithm *GreedyJoiningAlgorithm[data]) updatePartitioningArray(part1, part2 int) {
-	for i, part := range algorithm.partitioning {
-		if part == part2 {
-			algorithm.partitioning[i] = part1
-		} else if part > part2 {
-			algorithm.partitioning[i]--
+// Writes the member lists of the partitions into the partitioning array
+func (algorithm *GreedyJoiningAlgorithm[data]) updatePartitioningArray() {
+	for partition, list := range algorithm.partitions {
+		iter := list.Iterator()
+		for iter.HasNext() {
+			algorithm.partitioning[iter.Next()] = partition
 		}
 	}
 }
 
+// Returns the current partitioning. The joins only update the member lists of the partitions,
+// so the partitioning array is updated from them first.
+func (algorithm *GreedyJoiningAlgorithm[data]) Partitioning() PartitioningArray {
+	if algorithm.partitions != nil {
+		algorithm.updatePartitioningArray()
+	}
+	return algorithm.partitioning
+}
+
 // --------------------------
 
 // This function can be used to get a GreedyJoiningAlgorithm struct outside of this package,
@@ -209,6 +248,8 @@ func (algorithm *GreedyJoiningAlgorithm[data]) InitializeAlgorithm() ([2]int, fl
 	algorithm.partitioning.InitializeSingletonSets(len(*algorithm.input))
 	costs, bestJoinOverall, bestJoinCostOverall := InitializeCosts(algorithm.input, algorithm.calc)
 	algorithm.costs = &costs
+	algorithm.initializePartitions()
+	algorithm.initializeBestJoins()
 	return bestJoinOverall, bestJoinCostOverall
 }
 
@@ -251,6 +292,9 @@ func InitializeCosts[data any](input *[]data, calc CostCalculator[data]) (Costs,
 			minCost:            minCost2Dim,
 			bestJoin:           bestJoin,
 			twoPartitionsCosts: onePartitionCosts,
+			joinCosts: utils.CreateTournamentTree(utils.Map(onePartitionCosts, func(element *TwoPartitionsCosts) float64 {
+				return element.joinCost
+			})),
 		}
 		if minCost2Dim < bestJoinCostOverall {
 			bestJoinCostOverall = minCost2Dim
@@ -266,34 +310,39 @@ func InitializeCosts[data any](input *[]data, calc CostCalculator[data]) (Costs,
 // which arises when joining the two partitions of which the indices are
 // given to this function. It returns the indices of two partitions
 // which have the best join cost in the new data structure and the cost.
+// The partitioning array isn't updated, use Partitioning to get the new partitioning.
 func (algorithm *GreedyJoiningAlgorithm[data]) Join(part1, part2 int) ([2]int, float64) {
 	algorithm.costs.verifyIndices(&part1, &part2)
 
 	previousJoinCost := algorithm.costs.RealJoinCost(part1, part2)
 
-	bestJoinOverall := [2]int{-1, -1}
-	bestJoinCostOverall := math.Inf(1)
+	if algorithm.partitions == nil {
+		algorithm.initializePartitions()
+	}
+	if algorithm.bestJoins == nil {
+		algorithm.initializeBestJoins()
+	}
 
-	algorithm.joinStep1(part1, part2, previousJoinCost, &bestJoinOverall, &bestJoinCostOverall)
+	algorithm.joinStep1(part1, part2, previousJoinCost)
 
 	// if part1 is the second last partition it can just be removed because after the join it'll be the last
 	// partition which is not stored in the data structure
 	if part1 == len(*algorithm.costs)-1 {
 		*algorithm.costs = (*algorithm.costs)[:part1]
-		algorithm.updatePartitioningArray(part1, part2)
-		return bestJoinOverall, bestJoinCostOverall
+		algorithm.bestJoins.Delete(part1)
+		algorithm.updatePartitions(part1, part2)
+		return algorithm.bestJoin()
 	}
-	algorithm.joinStep2(part1, part2, previousJoinCost, &bestJoinOverall, &bestJoinCostOverall)
-	algorithm.joinStep3(part1, part2, previousJoinCost, &bestJoinOverall, &bestJoinCostOverall)
-	algorithm.joinStep4(part1, part2, previousJoinCost, &bestJoinOverall, &bestJoinCostOverall)
+	algorithm.joinStep2(part1, part2, previousJoinCost)
+	algorithm.joinStep3(part1, part2)
+	algorithm.joinStep4(part1, part2)
 
-	algorithm.updatePartitioningArray(part1, part2)
+	algorithm.updatePartitions(part1, part2)
 
-	return bestJoinOverall, bestJoinCostOverall
+	return algorithm.bestJoin()
 }
 
-func (algorithm *GreedyJoiningAlgorithm[data]) joinStep1(part1, part2 int, previousJoinCost float64,
-	bestJoinOverall *[2]int, bestJoinCostOverall *float64) {
+func (algorithm *GreedyJoiningAlgorithm[data]) joinStep1(part1, part2 int, previousJoinCost float64) {
 	// Cache that stores join(i, part1) + join(i, part2) + TripleCost3Part(i, part1, part2) with i being the key
 	cache := make(map[int]float64)
 	// loop through first dimension until part1
@@ -352,6 +401,7 @@ func (algorithm *GreedyJoiningAlgorithm[data]) joinStep1(part1, part2 int, previ
 					twoPartitionsCosts.joinCost = joinCost
 					twoPartitionsCosts.bestJoin = bestJoin
 				}
+				algorithm.costs.setJoinCost(i, j, twoPartitionsCosts.joinCost)
 			}
 		}
 		{
@@ -359,13 +409,13 @@ func (algorithm *GreedyJoiningAlgorithm[data]) joinStep1(part1, part2 int, previ
 			twoPartitionsCosts := ((*(algorithm.costs))[i]).twoPartitionsCosts[index2DPart1]
 			if twoPartitionsCosts.tripleJoinCosts != nil {
 				// if i and part1 both have 1 element, then the new join cost has already been calculated
-				twoPartitionsCosts.joinCost = (*twoPartitionsCosts.tripleJoinCosts)[algorithm.costs.GetIndex(part1, part2)] - previousJoinCost
+				algorithm.costs.setJoinCost(i, index2DPart1, (*twoPartitionsCosts.tripleJoinCosts)[algorithm.costs.GetIndex(part1, part2)]-previousJoinCost)
 				twoPartitionsCosts.tripleJoinCosts = nil
 			} else {
 				// the new cost require some additional calculations: the cost of triples where each element is in
 				// i, part1 and part2 respectively
 				additionalCost := algorithm.TripleCost3Part(i, part1, part2)
-				twoPartitionsCosts.joinCost = algorithm.costs.RealJoinCost(i, part1) + algorithm.costs.RealJoinCost(i, part2) + additionalCost
+				algorithm.costs.setJoinCost(i, index2DPart1, algorithm.costs.RealJoinCost(i, part1)+algorithm.costs.RealJoinCost(i, part2)+additionalCost)
 			}
 		}
 		// determine the index where part2 is located in the second dimension
@@ -381,14 +431,13 @@ func (algorithm *GreedyJoiningAlgorithm[data]) joinStep1(part1, part2 int, previ
 		// delete part2 in the second dimension
 		algorithm.costs.Delete2D(i, part2)
 
-		algorithm.costs.Min2D(i, bestJoinOverall, bestJoinCostOverall)
+		algorithm.updateMin2D(i)
 	}
 }
 
 // Adjust the join costs in second dimension of partition part1. If the join procedure can be
 // ended after this function it returns true.
-func (algorithm *GreedyJoiningAlgorithm[data]) joinStep2(part1, part2 int, previousJoinCost float64,
-	bestJoinOverall *[2]int, bestJoinCostOverall *float64) {
+func (algorithm *GreedyJoiningAlgorithm[data]) joinStep2(part1, part2 int, previousJoinCost float64) {
 
 	twoPartitionsCosts := ((*(algorithm.costs))[part1]).twoPartitionsCosts
 	index2DPart2 := algorithm.costs.GetIndex(part1, part2)
@@ -414,13 +463,13 @@ func (algorithm *GreedyJoiningAlgorithm[data]) joinStep2(part1, part2 int, previ
 			if err != nil {
 				panic(fmt.Errorf("The algorithm tried to access triple costs for partitions %d, %d and %d, but these costs weren't present!", part1, part2, jPartition))
 			}
-			twoPartitionsCosts[j].joinCost = tripleCost - previousJoinCost
+			algorithm.costs.setJoinCost(part1, j, tripleCost-previousJoinCost)
 			twoPartitionsCosts[j].tripleJoinCosts = nil
 		} else {
 			// the new cost require some additional calculations: the cost of triples where each element is in
 			// j, part1 and part2 respectively
 			additionalCost := algorithm.TripleCost3Part(part1, jPartition, part2)
-			twoPartitionsCosts[j].joinCost = algorithm.costs.RealJoinCost(part1, jPartition) + algorithm.costs.RealJoinCost(jPartition, part2) + additionalCost
+			algorithm.costs.setJoinCost(part1, j, algorithm.costs.RealJoinCost(part1, jPartition)+algorithm.costs.RealJoinCost(jPartition, part2)+additionalCost)
 			twoPartitionsCosts[j].tripleJoinCosts = nil
 		}
 	}
@@ -428,11 +477,10 @@ func (algorithm *GreedyJoiningAlgorithm[data]) joinStep2(part1, part2 int, previ
 	algorithm.costs.Delete2D(part1, part2)
 
 	// recompute the minimum over all joins of part1
-	algorithm.costs.Min2D(part1, bestJoinOverall, bestJoinCostOverall)
+	algorithm.updateMin2D(part1)
 }
 
-func (algorithm *GreedyJoiningAlgorithm[data]) joinStep3(part1, part2 int, previousJoinCost float64,
-	bestJoinOverall *[2]int, bestJoinCostOverall *float64) {
+func (algorithm *GreedyJoiningAlgorithm[data]) joinStep3(part1, part2 int) {
 	// Delete part2 in second and third dimension of all elements in first dimension between part1 and part2
 	for i := part1 + 1; i < part2; i++ {
 		twoPartitionsCosts := (*algorithm.costs)[i].twoPartitionsCosts
@@ -449,28 +497,19 @@ func (algorithm *GreedyJoiningAlgorithm[data]) joinStep3(part1, part2 int, previ
 		algorithm.costs.Delete2D(i, part2)
 
 		// recompute the minimum over all joins of partition i
-		algorithm.costs.Min2D(i, bestJoinOverall, bestJoinCostOverall)
+		algorithm.updateMin2D(i)
 	}
 }
 
 // Delete costs for part2 in first dimension
-func (algorithm *GreedyJoiningAlgorithm[data]) joinStep4(part1, part2 int, previousJoinCost float64,
-	bestJoinOverall *[2]int, bestJoinCostOverall *float64) {
+func (algorithm *GreedyJoiningAlgorithm[data]) joinStep4(part1, part2 int) {
 	// check if part2 is the last partition which is not stored in the data structure
 	if part2 == len(*algorithm.costs) {
 		*algorithm.costs = (*algorithm.costs)[:len(*algorithm.costs)-1]
+		algorithm.bestJoins.Delete(len(*algorithm.costs))
 	} else {
 		*algorithm.costs = append((*algorithm.costs)[:part2], (*algorithm.costs)[part2+1:]...)
-	}
-
-	// Check if the joins in the partitions after part2 are better than the previously
-	// observed join costs
-	for i := part2; i < len(*algorithm.costs); i++ {
-		if onePartitionCosts := (*algorithm.costs)[i]; onePartitionCosts.minCost < *bestJoinCostOverall {
-			*bestJoinCostOverall = onePartitionCosts.minCost
-			(*bestJoinOverall)[0] = i
-			(*bestJoinOverall)[1] = i + onePartitionCosts.bestJoin + 1
-		}
+		algorithm.bestJoins.Delete(part2)
 	}
 }
 
@@ -479,11 +518,18 @@ func (algorithm *GreedyJoiningAlgorithm[data]) joinStep4(part1, part2 int, previ
 // 	- it only joins 2 partitions
 // 	- if there is only one partition left the algorithm terminates
 func GreedyJoining[data any](input *[]data, calc CostCalculator[data]) PartitioningArray {
+	return GreedyJoiningPhases(input, calc)()
+}
+
+// The greedy joining algorithm split into its phases (see PhasedAlgorithm)
+func GreedyJoiningPhases[data any](input *[]data, calc CostCalculator[data]) func() PartitioningArray {
 	algorithm := GreedyJoiningAlgorithm[data]{input: input, calc: calc}
 	nextJoin, costDiff := algorithm.InitializeAlgorithm()
 
-	for costDiff < 0 && nextJoin[0] != -1 && nextJoin[1] != -1 {
-		nextJoin, costDiff = algorithm.Join(nextJoin[0], nextJoin[1])
+	return func() PartitioningArray {
+		for costDiff < 0 && nextJoin[0] != -1 && nextJoin[1] != -1 {
+			nextJoin, costDiff = algorithm.Join(nextJoin[0], nextJoin[1])
+		}
+		return algorithm.Partitioning()
 	}
-	return algorithm.partitioning
 }
@@ -147,6 +147,7 @@ func TestPartsInMiddle(t *testing.T) {
 	algorithm.InitializeAlgorithm()
 	bestJoin, bestCost := algorithm.Join(5, 8)
 	costs := *algorithm.costs
+	assert.Equal(t, PartitioningArray{0, 1, 2, 3, 4, 5, 6, 7, 5, 8}, algorithm.Partitioning(), "The element 8 should be in partition 5")
 
 	assert.Equal(t, 0, bestJoin[0], "Next join with cost -1 is between the partitions 0 and 3")
 	assert.Equal(t, 3, bestJoin[1], "Next join with cost -1 is between the partitions 0 and 3")
@@ -16,25 +16,21 @@ type GreedyMovingAlgorithm[data any] struct {
 	tripleCosts  *TripleCosts
 	removeCosts  RemoveCosts
 	costs        *GreedyMovingCosts
+	workers      int
 }
 
-type RemoveCosts struct {
-	costs map[int]float64
-	mutex sync.RWMutex
-}
+// Stores the change of the remove cost of every element during one move. The
+// elements are split into contiguous chunks and every worker only reads and writes
+// the entries of its own chunk, thus the chunks act as per-worker buffers and no
+// locking is required.
+type RemoveCosts []float64
 
-func (rmCost *RemoveCosts) Set(key int, value float64) {
-	rmCost.mutex.Lock()
-	rmCost.costs[key] = value
-	rmCost.mutex.Unlock()
+func (rmCost RemoveCosts) Set(key int, value float64) {
+	rmCost[key] = value
 }
 
-func (rmCost *RemoveCosts) Get(key int) float64 {
-	rmCost.mutex.RLock()
-	value := rmCost.costs[key]
-	rmCost.mutex.RUnlock()
-
-	return value
+func (rmCost RemoveCosts) Get(key int) float64 {
+	return rmCost[key]
 }
 
 type GreedyMovingCosts []*MovingSecondDim
@@ -100,6 +96,13 @@ func CreateGreedyMovingAlgorithm[data any](input *[]data, calc CostCalculator[da
 		costs:        costs}
 }
 
+// Sets the number of workers which are used to update the costs after a move. If
+// workers is not positive, GOMAXPROCS workers are used. With one worker all updates
+// are computed sequentially.
+func (algorithm *GreedyMovingAlgorithm[data]) SetWorkers(workers int) {
+	algorithm.workers = workers
+}
+
 // Gets the triple cost array from an algorithm
 func (algorithm *GreedyMovingAlgorithm[data]) GetTripleCostArray() TripleCosts {
 	return *algorithm.tripleCosts
@@ -348,11 +351,8 @@ func (algorithm *GreedyMovingAlgorithm[data]) updateMoveCostTarget(element, rEle
 // into a singleton. UminSource is the smallest element of the previous partition of the
 // element without the element itself or -1 if the element was in a singleton.
 func (algorithm *GreedyMovingAlgorithm[data]) updatePartitioning(UminSource, UminDest, element int) {
-	var waitGroup sync.WaitGroup
-
 	// update partitioning array
-	waitGroup.Add(1)
-	go func() {
+	updateArray := func() {
 		var formerSourceRepresentative int
 		var newDestRepresentative int
 		if element < UminSource {
@@ -374,12 +374,10 @@ func (algorithm *GreedyMovingAlgorithm[data]) updatePartitioning(UminSource, Umi
 				algorithm.partitioning[i] = newDestRepresentative
 			}
 		}
-		waitGroup.Done()
-	}()
+	}
 
 	// update partitions map
-	waitGroup.Add(1)
-	go func() {
+	updateMap := func() {
 		if UminSource != -1 {
 			utils.DeleteByElement(algorithm.partitions[UminSource], element)
 		}
@@ -389,6 +387,21 @@ func (algorithm *GreedyMovingAlgorithm[data]) updatePartitioning(UminSource, Umi
 		} else {
 			algorithm.partitions[element] = &[]int{element}
 		}
+	}
+
+	if utils.DefaultWorkers(algorithm.workers) == 1 {
+		updateArray()
+		updateMap()
+		return
+	}
+	var waitGroup sync.WaitGroup
+	waitGroup.Add(2)
+	go func() {
+		updateArray()
+		waitGroup.Done()
+	}()
+	go func() {
+		updateMap()
 		waitGroup.Done()
 	}()
 	waitGroup.Wait()
@@ -465,7 +478,6 @@ func (algorithm *GreedyMovingAlgorithm[data]) Move(partition, element int) ([3]i
 	U, a, b := -1, -1, -1
 	n := len(*algorithm.input)
 	bestCostOverall := math.Inf(1)
-	algorithm.removeCosts = RemoveCosts{costs: make(map[int]float64), mutex: sync.RWMutex{}}
 
 	// The smallest element in the partition of the removed element without the element itself
 	UminSource := -1
@@ -494,30 +506,22 @@ func (algorithm *GreedyMovingAlgorithm[data]) Move(partition, element int) ([3]i
 	// The destination partition
 	destPart := algorithm.partitions[partition]
 
-	var waitGroup sync.WaitGroup
-
-	for i := 0; i < n; i++ {
-		waitGroup.Add(1)
-		go func(i int) {
+	utils.ParallelFor(n, algorithm.workers, func(worker, low, high int) {
+		for i := low; i < high; i++ {
+			algorithm.removeCosts.Set(i, 0)
 			algorithm.firstStage(i, element, UminSource, UminDest, ePart, destPart)
-			waitGroup.Done()
-		}(i)
-	}
-
-	waitGroup.Wait()
+		}
+	})
 
 	algorithm.updatePartitioning(UminSource, UminDest, element)
 
 	// Update new bestCost for element i, this must be done in a new loop because it
 	// uses the adjusted costs of other elements
-	for i := 0; i < n; i++ {
-		waitGroup.Add(1)
-		go func(i int) {
+	utils.ParallelFor(n, algorithm.workers, func(worker, low, high int) {
+		for i := low; i < high; i++ {
 			algorithm.secondStage(i)
-			waitGroup.Done()
-		}(i)
-	}
-	waitGroup.Wait()
+		}
+	})
 
 	// check if the bestMove for i is better overall
 	for i := range *algorithm.costs {
@@ -679,6 +683,7 @@ func (algorithm *GreedyMovingAlgorithm[data]) Initialize() ([3]int, float64) {
 	for i := 0; i < n; i++ {
 		algorithm.partitions[i] = &[]int{i}
 	}
+	algorithm.removeCosts = make(RemoveCosts, n)
 	return algorithm.InitializeCosts()
 }
 
@@ -688,9 +693,25 @@ func (algorithm *GreedyMovingAlgorithm[data]) Initialize() ([3]int, float64) {
 //		otherwise it will move 2 elements
 // 	- if there is only one partition left the algorithm terminates
 func GreedyMoving[data any](input *[]data, calc CostCalculator[data]) PartitioningArray {
+	return GreedyMovingPhases(input, calc)()
+}
+
+// The greedy moving algorithm split into its phases (see PhasedAlgorithm)
+func GreedyMovingPhases[data any](input *[]data, calc CostCalculator[data]) func() PartitioningArray {
 	algorithm := GreedyMovingAlgorithm[data]{input: input, calc: calc}
 	nextMove, costDiff := algorithm.Initialize()
+	return func() PartitioningArray {
+		return algorithm.search(nextMove, costDiff)
+	}
+}
 
+// Initializes the algorithm and performs the best move as long as it improves the costs
+func (algorithm *GreedyMovingAlgorithm[data]) run() PartitioningArray {
+	return algorithm.search(algorithm.Initialize())
+}
+
+// Performs the best move as long as it improves the costs, starting with the given move
+func (algorithm *GreedyMovingAlgorithm[data]) search(nextMove [3]int, costDiff float64) PartitioningArray {
 	for costDiff < 0 && nextMove[1] != -1 {
 		newNextMove, newCostDiff := algorithm.Move(nextMove[0], nextMove[1])
 		if nextMove[2] != -1 {
@@ -703,6 +724,15 @@ func GreedyMoving[data any](input *[]data, calc CostCalculator[data]) Partitioni
 	return algorithm.partitioning
 }
 
+// Returns the greedy moving algorithm which uses the given number of workers to update the
+// costs after every move (see SetWorkers)
+func GreedyMovingWithWorkers[data any](workers int) PartitioningAlgorithm[data] {
+	return func(input *[]data, calc CostCalculator[data]) PartitioningArray {
+		algorithm := GreedyMovingAlgorithm[data]{input: input, calc: calc, workers: workers}
+		return algorithm.run()
+	}
+}
+
 // The same as the ImprovedGreedyMoving algorithm but you can specify the path to a constraint
 // file. These constraints will be considered by the algorithm.
 func GreedyMovingWithConstraints[data any](input *[]data, calc CostCalculator[data], path string) PartitioningArray {
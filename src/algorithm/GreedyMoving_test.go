@@ -201,3 +201,13 @@ func TestMove(t *testing.T) {
 		assert.False(t, (*algorithm.costs)[8].moves[6].valid)
 	})
 }
+
+func TestGreedyMovingWithWorkers(t *testing.T) {
+	dataPoints := []string{"b", "c", "hello", "but", "howdy", "charley", "big", "delta", "brother", "humor"}
+	expected := GreedyMoving[string](&dataPoints, &CharCostCalc{})
+
+	for _, workers := range []int{1, 2, 3, len(dataPoints) + 1} {
+		partitioning := GreedyMovingWithWorkers[string](workers)(&dataPoints, &CharCostCalc{})
+		assert.Equal(t, expected, partitioning, "The number of workers must not change the result")
+	}
+}
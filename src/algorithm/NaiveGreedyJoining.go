@@ -15,6 +15,11 @@ type NaiveGreedyJoiningAlgorithm[data any] struct {
 }
 
 func NaiveGreedyJoining[data any](input *[]data, calc CostCalculator[data]) PartitioningArray {
+	return NaiveGreedyJoiningPhases(input, calc)()
+}
+
+// The naive greedy joining algorithm split into its phases (see PhasedAlgorithm)
+func NaiveGreedyJoiningPhases[data any](input *[]data, calc CostCalculator[data]) func() PartitioningArray {
 	n := len(*input)
 
 	algorithm := NaiveGreedyJoiningAlgorithm[data]{input: input, calc: calc}
@@ -28,13 +33,15 @@ func NaiveGreedyJoining[data any](input *[]data, calc CostCalculator[data]) Part
 		algorithm.partitionList[i] = i
 	}
 
-	nextJoin, costDiff := algorithm.FindBestJoin()
-	for costDiff < 0 {
-		algorithm.join(nextJoin[0], nextJoin[1])
-		nextJoin, costDiff = algorithm.FindBestJoin()
-	}
+	return func() PartitioningArray {
+		nextJoin, costDiff := algorithm.FindBestJoin()
+		for costDiff < 0 {
+			algorithm.join(nextJoin[0], nextJoin[1])
+			nextJoin, costDiff = algorithm.FindBestJoin()
+		}
 
-	return algorithm.partitioning
+		return algorithm.partitioning
+	}
 }
 
 func (algorithm *NaiveGreedyJoiningAlgorithm[data]) FindBestJoin() (bestJoin [2]int, minCostDiff float64) {
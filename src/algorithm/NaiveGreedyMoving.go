@@ -15,21 +15,28 @@ type NaiveGreedyMovingAlgorithm[data any] struct {
 }
 
 func NaiveGreedyMoving[data any](input *[]data, calc CostCalculator[data]) PartitioningArray {
+	return NaiveGreedyMovingPhases(input, calc)()
+}
+
+// The naive greedy moving algorithm split into its phases (see PhasedAlgorithm)
+func NaiveGreedyMovingPhases[data any](input *[]data, calc CostCalculator[data]) func() PartitioningArray {
 	algorithm := NaiveGreedyMovingAlgorithm[data]{input: input, calc: calc}
 	algorithm.initialize()
 
-	nextMove, costDiff := algorithm.findBestMove()
-	U, a, b := nextMove[0], nextMove[1], nextMove[2]
+	return func() PartitioningArray {
+		nextMove, costDiff := algorithm.findBestMove()
+		U, a, b := nextMove[0], nextMove[1], nextMove[2]
 
-	for costDiff < 0 {
-		algorithm.moveElement(U, a)
-		if b != -1 {
-			algorithm.moveElement(U, b)
+		for costDiff < 0 {
+			algorithm.moveElement(U, a)
+			if b != -1 {
+				algorithm.moveElement(U, b)
+			}
+			nextMove, costDiff = algorithm.findBestMove()
+			U, a, b = nextMove[0], nextMove[1], nextMove[2]
 		}
-		nextMove, costDiff = algorithm.findBestMove()
-		U, a, b = nextMove[0], nextMove[1], nextMove[2]
+		return algorithm.partitioning
 	}
-	return algorithm.partitioning
 }
 
 func (algorithm *NaiveGreedyMovingAlgorithm[data]) findBestMove() (bestMove [3]int, minCostDiff float64) {
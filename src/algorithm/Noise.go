@@ -0,0 +1,38 @@
+package algorithm
+
+// The label of elements which don't belong to any partition
+const NoiseLabel = -1
+
+// Labels the elements of all partitions with fewer than `minSize` elements as noise. The other
+// partitions are numbered by the order of their first element. The given partitioning isn't
+// modified.
+func LabelNoise(partitioning PartitioningArray, minSize int) PartitioningArray {
+	sizes := make(map[int]int)
+	for _, partition := range partitioning {
+		sizes[partition]++
+	}
+
+	labels := make(map[int]int)
+	result := make(PartitioningArray, len(partitioning))
+	for element, partition := range partitioning {
+		if partition == NoiseLabel || sizes[partition] < minSize {
+			result[element] = NoiseLabel
+			continue
+		}
+		label, ok := labels[partition]
+		if !ok {
+			label = len(labels)
+			labels[partition] = label
+		}
+		result[element] = label
+	}
+	return result
+}
+
+// Returns the given algorithm whose partitions with fewer than `minSize` elements are
+// labeled as noise
+func WithNoiseLabel[data any](algorithm PartitioningAlgorithm[data], minSize int) PartitioningAlgorithm[data] {
+	return func(input *[]data, calc CostCalculator[data]) PartitioningArray {
+		return LabelNoise(algorithm(input, calc), minSize)
+	}
+}
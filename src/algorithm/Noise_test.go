@@ -0,0 +1,25 @@
+package algorithm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabelNoise(t *testing.T) {
+	partitioning := PartitioningArray{3, 3, 5, 3, 1, 1, 7, NoiseLabel}
+
+	assert.Equal(t, PartitioningArray{0, 0, NoiseLabel, 0, 1, 1, NoiseLabel, NoiseLabel}, LabelNoise(partitioning, 2))
+	assert.Equal(t, PartitioningArray{0, 0, NoiseLabel, 0, NoiseLabel, NoiseLabel, NoiseLabel, NoiseLabel}, LabelNoise(partitioning, 3))
+	assert.Equal(t, PartitioningArray{0, 0, 1, 0, 2, 2, 3, NoiseLabel}, LabelNoise(partitioning, 0), "Elements which are already noise stay noise")
+	assert.Equal(t, PartitioningArray{3, 3, 5, 3, 1, 1, 7, NoiseLabel}, partitioning, "The input shouldn't be modified")
+}
+
+func TestWithNoiseLabel(t *testing.T) {
+	dataPoints := []string{"b", "c", "hello", "but", "howdy", "charley", "big", "delta", "brother", "humor"}
+	partitioning := NaiveGreedyJoining[string](&dataPoints, &CharCostCalc{})
+
+	assert.Equal(t, LabelNoise(partitioning, 3), WithNoiseLabel(NaiveGreedyJoining[string], 3)(&dataPoints, &CharCostCalc{}))
+	assert.Equal(t, 0.0, Objective[string](&dataPoints, &CharCostCalc{}, PartitioningArray{NoiseLabel, NoiseLabel, NoiseLabel}),
+		"Noise isn't a partition")
+}
@@ -0,0 +1,56 @@
+package algorithm
+
+// A cost calculator which computes the triple costs of all triples of distinct elements of an
+// input in advance. Afterwards the costs are only looked up, so it can be used concurrently and
+// by several algorithms on the same input. The costs of all other triples are passed to the
+// wrapped calculator.
+type PrecomputedCostCalculator[data any] struct {
+	calc    CostCalculator[data]
+	indices map[*data]int
+	costs   []float64 // The cost of the triple i < j < k is at index tripleIndex(i, j, k)
+}
+
+// Computes the triple costs of all triples of distinct elements in the given input. The returned
+// calculator must only be used with this input.
+func PrecomputeCosts[data any](input *[]data, calc CostCalculator[data]) *PrecomputedCostCalculator[data] {
+	n := len(*input)
+	indices := make(map[*data]int, n)
+	for i := range *input {
+		indices[&(*input)[i]] = i
+	}
+	costs := make([]float64, n*(n-1)*(n-2)/6)
+	for k := 2; k < n; k++ {
+		for j := 1; j < k; j++ {
+			for i := 0; i < j; i++ {
+				costs[tripleIndex(i, j, k)] = calc.TripleCost(&(*input)[i], &(*input)[j], &(*input)[k])
+			}
+		}
+	}
+	return &PrecomputedCostCalculator[data]{calc: calc, indices: indices, costs: costs}
+}
+
+// Returns the precomputed triple cost of the given elements
+func (precomputed *PrecomputedCostCalculator[data]) TripleCost(d1, d2, d3 *data) float64 {
+	i, ok1 := precomputed.indices[d1]
+	j, ok2 := precomputed.indices[d2]
+	k, ok3 := precomputed.indices[d3]
+	if !ok1 || !ok2 || !ok3 || i == j || j == k || i == k {
+		return precomputed.calc.TripleCost(d1, d2, d3)
+	}
+	if i > j {
+		i, j = j, i
+	}
+	if j > k {
+		j, k = k, j
+	}
+	if i > j {
+		i, j = j, i
+	}
+	return precomputed.costs[tripleIndex(i, j, k)]
+}
+
+// Returns the index of the triple i < j < k in the combinatorial number system, so the triples
+// of the first n elements have the indices 0 to (n choose 3) - 1
+func tripleIndex(i, j, k int) int {
+	return k*(k-1)*(k-2)/6 + j*(j-1)/2 + i
+}
@@ -0,0 +1,46 @@
+package algorithm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// The cost of a triple is the sum of its elements, s.t. every triple has a different cost
+type SumCostCalc struct{}
+
+func (calc SumCostCalc) TripleCost(d1, d2, d3 *float64) float64 {
+	return *d1 + *d2 + *d3
+}
+
+func TestPrecomputedCostCalculator(t *testing.T) {
+	dataPoints := []string{"b", "c", "hello", "but", "howdy", "bye"}
+	calc := &CountingCostCalc{}
+	precomputed := PrecomputeCosts[string](&dataPoints, calc)
+	assert.Equal(t, 20, calc.calls, "Every triple should be computed once")
+
+	assert.Equal(t, 1.0, precomputed.TripleCost(&dataPoints[0], &dataPoints[1], &dataPoints[2]))
+	assert.Equal(t, -1.0, precomputed.TripleCost(&dataPoints[5], &dataPoints[0], &dataPoints[3]), "The order of the elements doesn't matter")
+	assert.Equal(t, 20, calc.calls)
+
+	assert.Equal(t, -1.0, precomputed.TripleCost(&dataPoints[2], &dataPoints[4], &dataPoints[4]))
+	other := "hi"
+	assert.Equal(t, -1.0, precomputed.TripleCost(&dataPoints[2], &dataPoints[4], &other))
+	assert.Equal(t, 22, calc.calls, "The costs of other triples should be computed by the wrapped calculator")
+
+	numbers := []float64{1, 2, 4, 8, 16, 32}
+	precomputedSums := PrecomputeCosts[float64](&numbers, SumCostCalc{})
+	for i := range numbers {
+		for j := range numbers {
+			for k := range numbers {
+				if i != j && j != k && i != k {
+					assert.Equal(t, numbers[i]+numbers[j]+numbers[k], precomputedSums.TripleCost(&numbers[i], &numbers[j], &numbers[k]))
+				}
+			}
+		}
+	}
+
+	assert.Equal(t, GreedyJoining[string](&dataPoints, CharCostCalc{}), GreedyJoining[string](&dataPoints, PrecomputeCosts[string](&dataPoints, CharCostCalc{})))
+	empty := []string{"a"}
+	assert.Equal(t, 0, len(PrecomputeCosts[string](&empty, CharCostCalc{}).costs))
+}
@@ -0,0 +1,426 @@
+package algorithm
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/utils"
+)
+
+// The parameters for the sampled greedy algorithm
+type SamplingParameters struct {
+	Budget     int   // How many triples are sampled at most to estimate the cost of one join or move
+	Candidates int   // How many join partners are sampled for every element at the start of the joining phase
+	Seed       int64 // The seed for the random number generator which samples the triples
+}
+
+// The parameters that are used if the sampled greedy algorithm is selected by its name
+var DefaultSamplingParameters = SamplingParameters{Budget: 100, Candidates: 20, Seed: 0}
+
+// How often the moving phase of the sampled greedy algorithm iterates over all elements at most
+const maxMovingPasses = 10
+
+// This struct holds all the information that is necessary to perform the sampled
+// greedy algorithm
+type SampledGreedyAlgorithm[data any] struct {
+	input        *[]data
+	calc         CostCalculator[data]
+	budget       int
+	candidates   int
+	random       *rand.Rand
+	partitioning PartitioningArray     // Maps every element to the id of its partition
+	partitions   map[int][]int         // Maps the id of a partition to its elements
+	neighbors    []map[int]float64     // The estimated join costs of every partition of the joining phase with its candidates
+	bestJoin     []int                 // The candidate with the smallest join cost of every partition, -1 if there is none
+	bestJoins    *utils.TournamentTree // The smallest join cost of every partition, indexed by the id of the partition
+	nextId       int                   // The id for the next partition that is created in the moving phase
+}
+
+// Returns an approximate greedy algorithm for large inputs. It estimates the cost differences of
+// joins and moves from a random sample of at most `Budget` triples instead of summing over
+// all triples. If there are fewer triples than the budget, the exact cost difference is computed.
+//
+// The algorithm has two phases:
+//   - a joining phase: only pairs of candidates are considered for joins. At the start every
+//     element gets `Candidates` sampled elements as candidates, a joined partition keeps the
+//     `Candidates` candidates of both partitions with the best join costs. So the number of
+//     considered pairs and the memory grow linearly with the number of elements. The algorithm joins the two candidates
+//     with the best join cost as long as it's negative. Two singleton sets contain no triple, so
+//     their join cost is replaced by a score: the smallest triple cost of the two elements with a
+//     third element. If there are at most `Budget` other elements, this is the minimum over all of
+//     them, otherwise over `Budget` sampled elements. It's no estimate of a cost difference but it
+//     prefers pairs which form a good triple.
+//   - a moving phase: it iterates over the elements and moves every element into the partition
+//     (or a new singleton set) with the best estimated move cost if it's negative
+func SampledGreedy[data any](parameters SamplingParameters) PartitioningAlgorithm[data] {
+	phases := SampledGreedyPhases[data](parameters)
+	return func(input *[]data, calc CostCalculator[data]) PartitioningArray {
+		return phases(input, calc)()
+	}
+}
+
+// The sampled greedy algorithm split into its phases (see PhasedAlgorithm), the initialization
+// estimates the join costs of all singleton sets
+func SampledGreedyPhases[data any](parameters SamplingParameters) PhasedAlgorithm[data] {
+	if parameters.Budget < 1 {
+		panic("The sample budget must be positive")
+	}
+	if parameters.Candidates < 1 {
+		panic("The number of candidates must be positive")
+	}
+	return func(input *[]data, calc CostCalculator[data]) func() PartitioningArray {
+		algorithm := SampledGreedyAlgorithm[data]{
+			input:      input,
+			calc:       calc,
+			budget:     parameters.Budget,
+			candidates: parameters.Candidates,
+			random:     rand.New(rand.NewSource(parameters.Seed)),
+		}
+		algorithm.initialize()
+		return func() PartitioningArray {
+			algorithm.joiningPhase()
+			algorithm.movingPhase()
+			return algorithm.relabel()
+		}
+	}
+}
+
+// Returns the triple cost of the elements at the given indices
+func (algorithm *SampledGreedyAlgorithm[data]) tripleCost(i, j, k int) float64 {
+	return algorithm.calc.TripleCost(&(*algorithm.input)[i], &(*algorithm.input)[j], &(*algorithm.input)[k])
+}
+
+// Returns the index of a random pair (i, j) with i < j out of a set with the given size
+func (algorithm *SampledGreedyAlgorithm[data]) randomPair(size int) (int, int) {
+	i := algorithm.random.Intn(size)
+	j := algorithm.random.Intn(size - 1)
+	if j >= i {
+		j++
+	} else {
+		i, j = j, i
+	}
+	return i, j
+}
+
+// Estimates the sum of the triple costs of the given element with every pair of the given elements
+func (algorithm *SampledGreedyAlgorithm[data]) estimatePairSum(element int, elements []int) float64 {
+	m := len(elements)
+	pairs := m * (m - 1) / 2
+	sum := 0.0
+	if pairs <= algorithm.budget {
+		for i := 0; i < m-1; i++ {
+			for j := i + 1; j < m; j++ {
+				sum += algorithm.tripleCost(element, elements[i], elements[j])
+			}
+		}
+		return sum
+	}
+	for s := 0; s < algorithm.budget; s++ {
+		i, j := algorithm.randomPair(m)
+		sum += algorithm.tripleCost(element, elements[i], elements[j])
+	}
+	return sum * float64(pairs) / float64(algorithm.budget)
+}
+
+// Estimates the cost difference of joining the two given partitions. At least one of the
+// partitions must contain more than one element.
+func (algorithm *SampledGreedyAlgorithm[data]) estimateJoinCost(part1, part2 int) float64 {
+	elements1 := algorithm.partitions[part1]
+	elements2 := algorithm.partitions[part2]
+	n1, n2 := len(elements1), len(elements2)
+
+	// triples with one element in part1 and two elements in part2 and vice versa
+	triples1 := n1 * n2 * (n2 - 1) / 2
+	triples2 := n2 * n1 * (n1 - 1) / 2
+
+	if triples1+triples2 <= algorithm.budget {
+		sum := 0.0
+		for _, element := range elements1 {
+			sum += algorithm.estimatePairSum(element, elements2)
+		}
+		for _, element := range elements2 {
+			sum += algorithm.estimatePairSum(element, elements1)
+		}
+		return sum
+	}
+
+	sum := 0.0
+	for s := 0; s < algorithm.budget; s++ {
+		if algorithm.random.Intn(triples1+triples2) < triples1 {
+			i, j := algorithm.randomPair(n2)
+			sum += algorithm.tripleCost(elements1[algorithm.random.Intn(n1)], elements2[i], elements2[j])
+		} else {
+			i, j := algorithm.randomPair(n1)
+			sum += algorithm.tripleCost(elements2[algorithm.random.Intn(n2)], elements1[i], elements1[j])
+		}
+	}
+	return sum * float64(triples1+triples2) / float64(algorithm.budget)
+}
+
+// Returns the score of joining the two given singleton sets, which is the smallest triple cost
+// of the two elements with a third element. The third element is either every other element
+// or, if there are more than the budget, a sampled one.
+func (algorithm *SampledGreedyAlgorithm[data]) estimateSingletonJoinCost(i, j int) float64 {
+	n := len(*algorithm.input)
+	minCost := math.Inf(1)
+	if n-2 <= algorithm.budget {
+		for k := 0; k < n; k++ {
+			if k != i && k != j {
+				minCost = math.Min(minCost, algorithm.tripleCost(i, j, k))
+			}
+		}
+		return minCost
+	}
+	for s := 0; s < algorithm.budget; s++ {
+		k := algorithm.random.Intn(n)
+		if k != i && k != j {
+			minCost = math.Min(minCost, algorithm.tripleCost(i, j, k))
+		}
+	}
+	return minCost
+}
+
+// Initializes the partitioning into singleton sets, samples the candidates of every element
+// and scores the joins of the candidates
+func (algorithm *SampledGreedyAlgorithm[data]) initialize() {
+	n := len(*algorithm.input)
+	algorithm.partitioning.InitializeSingletonSets(n)
+	algorithm.partitions = make(map[int][]int, n)
+	algorithm.neighbors = make([]map[int]float64, n)
+	algorithm.bestJoin = make([]int, n)
+	algorithm.nextId = n
+
+	for i := 0; i < n; i++ {
+		algorithm.partitions[i] = []int{i}
+		algorithm.neighbors[i] = make(map[int]float64)
+	}
+	for i := 0; i < n; i++ {
+		for _, j := range algorithm.sampleCandidates(i) {
+			if _, ok := algorithm.neighbors[i][j]; !ok {
+				cost := algorithm.estimateSingletonJoinCost(i, j)
+				algorithm.neighbors[i][j] = cost
+				algorithm.neighbors[j][i] = cost
+			}
+		}
+	}
+
+	minCosts := make([]float64, n)
+	for i := 0; i < n; i++ {
+		minCosts[i], algorithm.bestJoin[i] = algorithm.minJoinCost(i)
+	}
+	algorithm.bestJoins = utils.CreateTournamentTree(minCosts)
+}
+
+// Returns the candidates of the given element in ascending order. If there are at most as many
+// other elements as candidates, all of them are returned.
+func (algorithm *SampledGreedyAlgorithm[data]) sampleCandidates(element int) []int {
+	n := len(*algorithm.input)
+	if n-1 <= algorithm.candidates {
+		candidates := make([]int, 0, n-1)
+		for candidate := 0; candidate < n; candidate++ {
+			if candidate != element {
+				candidates = append(candidates, candidate)
+			}
+		}
+		return candidates
+	}
+	sampled := make(map[int]bool, algorithm.candidates)
+	candidates := make([]int, 0, algorithm.candidates)
+	for len(candidates) < algorithm.candidates {
+		candidate := algorithm.random.Intn(n - 1)
+		if candidate >= element {
+			candidate++
+		}
+		if !sampled[candidate] {
+			sampled[candidate] = true
+			candidates = append(candidates, candidate)
+		}
+	}
+	sort.Ints(candidates)
+	return candidates
+}
+
+// Returns the smallest join cost of the given partition with its candidates and the candidate.
+// If several candidates have this cost, the one with the smallest id is returned. If there is
+// none, +Inf and -1 are returned.
+func (algorithm *SampledGreedyAlgorithm[data]) minJoinCost(partition int) (float64, int) {
+	minCost, bestJoin := math.Inf(1), -1
+	for candidate, cost := range algorithm.neighbors[partition] {
+		if cost < minCost || (cost == minCost && candidate < bestJoin) {
+			minCost, bestJoin = cost, candidate
+		}
+	}
+	return minCost, bestJoin
+}
+
+// Recomputes the smallest join cost of the given partition
+func (algorithm *SampledGreedyAlgorithm[data]) updateBestJoin(partition int) {
+	minCost, bestJoin := algorithm.minJoinCost(partition)
+	algorithm.bestJoin[partition] = bestJoin
+	algorithm.bestJoins.Set(partition, minCost)
+}
+
+// Joins partitions with the best estimated join cost as long as this cost is negative
+func (algorithm *SampledGreedyAlgorithm[data]) joiningPhase() {
+	for {
+		minCost, part1 := algorithm.bestJoins.Min()
+		if part1 == -1 || minCost >= 0 {
+			return
+		}
+		algorithm.join(part1, algorithm.bestJoin[part1])
+	}
+}
+
+// Joins the partitions part1 and part2 into the one with the smaller id and estimates the join
+// costs of the joined partition with its candidates. These are the candidates of both partitions
+// with the best join costs before the join, at most as many as every element got at the start.
+func (algorithm *SampledGreedyAlgorithm[data]) join(part1, part2 int) {
+	if part2 < part1 {
+		part1, part2 = part2, part1
+	}
+	for _, element := range algorithm.partitions[part2] {
+		algorithm.partitioning[element] = part1
+	}
+	algorithm.partitions[part1] = append(algorithm.partitions[part1], algorithm.partitions[part2]...)
+	delete(algorithm.partitions, part2)
+
+	// the previous join cost of every candidate of the two partitions is the smaller one
+	previousCosts := make(map[int]float64, len(algorithm.neighbors[part1])+len(algorithm.neighbors[part2]))
+	for _, part := range []int{part1, part2} {
+		for candidate, cost := range algorithm.neighbors[part] {
+			delete(algorithm.neighbors[candidate], part)
+			if previous, ok := previousCosts[candidate]; candidate != part1 && candidate != part2 && (!ok || cost < previous) {
+				previousCosts[candidate] = cost
+			}
+		}
+	}
+	candidates := make([]int, 0, len(previousCosts))
+	for candidate := range previousCosts {
+		candidates = append(candidates, candidate)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		cost1, cost2 := previousCosts[candidates[i]], previousCosts[candidates[j]]
+		return cost1 < cost2 || (cost1 == cost2 && candidates[i] < candidates[j])
+	})
+	// only the candidates with the best previous join costs are kept
+	if len(candidates) > algorithm.candidates {
+		for _, dropped := range candidates[algorithm.candidates:] {
+			if best := algorithm.bestJoin[dropped]; best == part1 || best == part2 {
+				algorithm.updateBestJoin(dropped)
+			}
+		}
+		candidates = candidates[:algorithm.candidates]
+	}
+	// the candidates are processed in ascending order s.t. the same seed yields the same samples
+	sort.Ints(candidates)
+
+	algorithm.neighbors[part2] = nil
+	algorithm.bestJoin[part2] = -1
+	algorithm.bestJoins.Set(part2, math.Inf(1))
+	algorithm.neighbors[part1] = make(map[int]float64, len(candidates))
+	for _, candidate := range candidates {
+		cost := algorithm.estimateJoinCost(part1, candidate)
+		algorithm.neighbors[part1][candidate] = cost
+		algorithm.neighbors[candidate][part1] = cost
+
+		best := algorithm.bestJoin[candidate]
+		if best == part1 || best == part2 {
+			algorithm.updateBestJoin(candidate)
+		} else if bestCost := algorithm.bestJoins.Get(candidate); cost < bestCost || (cost == bestCost && part1 < best) {
+			algorithm.bestJoin[candidate] = part1
+			algorithm.bestJoins.Set(candidate, cost)
+		}
+	}
+	algorithm.updateBestJoin(part1)
+}
+
+// Returns the ids of all partitions in ascending order. The partitions are always processed
+// in this order s.t. the same seed yields the same samples.
+func (algorithm *SampledGreedyAlgorithm[data]) partitionIds() []int {
+	ids := make([]int, 0, len(algorithm.partitions))
+	for id := range algorithm.partitions {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// Returns the given elements without the given element
+func without(elements []int, element int) []int {
+	result := make([]int, 0, len(elements))
+	for _, e := range elements {
+		if e != element {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// Moves elements into the partition with the best estimated move cost until no move
+// with negative costs is found or the maximum number of passes is reached
+func (algorithm *SampledGreedyAlgorithm[data]) movingPhase() {
+	for pass := 0; pass < maxMovingPasses; pass++ {
+		moved := false
+		// partitions are only created with higher ids, so the ids stay sorted if they're appended
+		ids := algorithm.partitionIds()
+		for element := range algorithm.partitioning {
+			source := algorithm.partitioning[element]
+			rest := without(algorithm.partitions[source], element)
+
+			// the cost for removing the element from its partition
+			removeCost := -algorithm.estimatePairSum(element, rest)
+			bestCost := 0.0
+			bestPartition := -1
+			if len(rest) > 0 && removeCost < bestCost {
+				bestCost = removeCost
+				bestPartition = algorithm.nextId
+			}
+			for _, partition := range ids {
+				elements := algorithm.partitions[partition]
+				if partition == source || len(elements) < 2 {
+					continue
+				}
+				cost := removeCost + algorithm.estimatePairSum(element, elements)
+				if cost < bestCost {
+					bestCost = cost
+					bestPartition = partition
+				}
+			}
+			if bestPartition == -1 {
+				continue
+			}
+
+			if len(rest) == 0 {
+				delete(algorithm.partitions, source)
+			} else {
+				algorithm.partitions[source] = rest
+			}
+			if bestPartition == algorithm.nextId {
+				algorithm.nextId++
+				ids = append(ids, bestPartition)
+			}
+			algorithm.partitions[bestPartition] = append(algorithm.partitions[bestPartition], element)
+			algorithm.partitioning[element] = bestPartition
+			moved = true
+		}
+		if !moved {
+			return
+		}
+	}
+}
+
+// Relabels the partitions s.t. the partitions are numbered by the order of their first element
+func (algorithm *SampledGreedyAlgorithm[data]) relabel() PartitioningArray {
+	labels := make(map[int]int)
+	partitioning := make(PartitioningArray, len(algorithm.partitioning))
+	for element, partition := range algorithm.partitioning {
+		label, ok := labels[partition]
+		if !ok {
+			label = len(labels)
+			labels[partition] = label
+		}
+		partitioning[element] = label
+	}
+	return partitioning
+}
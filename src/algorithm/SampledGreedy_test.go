@@ -0,0 +1,55 @@
+package algorithm
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSampledGreedy(t *testing.T) {
+	dataPoints := []string{"b", "c", "hello", "but", "howdy", "charley", "big", "delta", "brother", "humor"}
+
+	// with a budget larger than the number of triples and all elements as candidates all costs are computed exactly
+	partitioning := SampledGreedy[string](SamplingParameters{Budget: 1000, Candidates: 100, Seed: 1})(&dataPoints, CharCostCalc{})
+	assert.Equal(t, PartitioningArray{0, 1, 2, 0, 2, 3, 0, 4, 0, 2}, partitioning)
+	assert.Equal(t, -5.0, Objective[string](&dataPoints, CharCostCalc{}, partitioning))
+
+	assert.Panics(t, func() { SampledGreedy[string](SamplingParameters{Budget: 0, Candidates: 1}) })
+	assert.Panics(t, func() { SampledGreedy[string](SamplingParameters{Budget: 1, Candidates: 0}) })
+}
+
+func TestSampledGreedyCandidates(t *testing.T) {
+	dataPoints := make([]string, 200)
+	for i := range dataPoints {
+		dataPoints[i] = string(rune('a'+i%5)) + strconv.Itoa(i)
+	}
+	parameters := SamplingParameters{Budget: 5, Candidates: 3, Seed: 7}
+
+	calc := &CountingCostCalc{}
+	search := SampledGreedyPhases[string](parameters)(&dataPoints, calc)
+	assert.LessOrEqual(t, calc.calls, len(dataPoints)*parameters.Candidates*parameters.Budget,
+		"Only the candidates of every element should be scored")
+	partitioning := search()
+	assert.Less(t, len(utils.ToSet(partitioning)), len(dataPoints), "Some candidates should be joined")
+	assert.Less(t, Objective[string](&dataPoints, CharCostCalc{}, partitioning), 0.0)
+}
+
+func TestSampledGreedyIsDeterministic(t *testing.T) {
+	dataPoints := []string{"b", "c", "hello", "but", "howdy", "charley", "big", "delta", "brother", "humor",
+		"bob", "cat", "dog", "hat", "cow", "bee", "dig", "how", "car", "den"}
+	parameters := SamplingParameters{Budget: 5, Candidates: 3, Seed: 42}
+
+	first := SampledGreedy[string](parameters)(&dataPoints, CharCostCalc{})
+	second := SampledGreedy[string](parameters)(&dataPoints, CharCostCalc{})
+	assert.Equal(t, first, second, "The same seed should yield the same partitioning")
+}
+
+func TestObjective(t *testing.T) {
+	dataPoints := []string{"b", "c", "but", "big", "cat"}
+
+	assert.Equal(t, 0.0, Objective[string](&dataPoints, CharCostCalc{}, PartitioningArray{0, 1, 2, 3, 4}))
+	assert.Equal(t, -1.0, Objective[string](&dataPoints, CharCostCalc{}, PartitioningArray{0, 1, 0, 0, 1}))
+	assert.Equal(t, 8.0, Objective[string](&dataPoints, CharCostCalc{}, PartitioningArray{0, 0, 0, 0, 0}))
+}
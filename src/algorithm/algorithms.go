@@ -15,6 +15,28 @@ func AlgorithmStringToFunc[data any](algorithm string) PartitioningAlgorithm[dat
 		return NaiveGreedyJoining[data]
 	case "NaiveGreedyMoving":
 		return NaiveGreedyMoving[data]
+	case "SampledGreedy":
+		return SampledGreedy[data](DefaultSamplingParameters)
+	default:
+		panic(fmt.Sprintf("Algorithm %s not supported", algorithm))
+	}
+}
+
+// Maps the names of the algorithms to the algorithms split into their phases
+func AlgorithmStringToPhasedFunc[data any](algorithm string) PhasedAlgorithm[data] {
+	switch algorithm {
+	case "":
+		panic("The algorithm was not specified")
+	case "GreedyJoining":
+		return GreedyJoiningPhases[data]
+	case "GreedyMoving":
+		return GreedyMovingPhases[data]
+	case "NaiveGreedyJoining":
+		return NaiveGreedyJoiningPhases[data]
+	case "NaiveGreedyMoving":
+		return NaiveGreedyMovingPhases[data]
+	case "SampledGreedy":
+		return SampledGreedyPhases[data](DefaultSamplingParameters)
 	default:
 		panic(fmt.Sprintf("Algorithm %s not supported", algorithm))
 	}
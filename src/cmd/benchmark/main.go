@@ -0,0 +1,276 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"runtime"
+	"runtime/metrics"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/algorithm"
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/geometry"
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/partitioning3D"
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/partitioning3D/evaluation"
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/utils"
+)
+
+// The metric of the runtime which contains the bytes of the objects on the heap
+const heapObjectsMetric = "/memory/classes/heap/objects:bytes"
+
+// The measurement of one phase of an algorithm
+type PhaseMeasurement struct {
+	Time           float64 // in milliseconds
+	Allocations    uint64  // The number of allocated objects
+	AllocatedBytes uint64
+	PeakHeapBytes  uint64 // The sampled peak of the heap during the phase minus the heap before the phase
+}
+
+// The result of one run of an algorithm
+type BenchmarkResult struct {
+	Algorithm      string
+	NumberOfPlanes int
+	PointsPerPlane int
+	Repetition     int
+	Seed           int64 // The seed from which the data was generated
+	Accuracy       float64
+	Initialization PhaseMeasurement
+	Search         PhaseMeasurement
+}
+
+func main() {
+	algorithms := flag.String("algorithms", "GreedyJoining", "A comma separated list of the algorithms which are benchmarked")
+	pointsPerPlane := flag.String("pointsPerPlane", "5,10,20,40", "A comma separated list of the numbers of points per plane")
+	numberOfPlanes := flag.String("numberOfPlanes", "3", "A comma separated list of the numbers of planes")
+	repetitions := flag.Int("repetitions", 3, "How many data sets are generated for every number of points per plane and number of planes")
+	stddev := flag.Float64("stddev", 0.01, "The standard deviation of the noise")
+	threshold := flag.Float64("threshold", 0, "The threshold for the cost calculation, 3 * stddev by default")
+	amplification := flag.Float64("amplification", 0, "The amplification for the cost calculation, 1 / stddev by default")
+	seed := flag.Int64("seed", 0, "The seed from which the seeds of the data sets are derived")
+	sampleInterval := flag.Duration("sampleInterval", time.Millisecond, "How often the heap is sampled to determine its peak")
+	output := flag.String("output", "", "Where the results should be written to, the format depends on the extension (.csv or .json)")
+	verbose := flag.Int("verbose", 0, "0: nothing will be printed, 1: every result will be printed")
+	flag.Parse()
+
+	if !strings.HasSuffix(*output, ".csv") && !strings.HasSuffix(*output, ".json") {
+		panic("The output file must be a csv or json file")
+	}
+	if *repetitions < 1 {
+		panic("The number of repetitions must be positive")
+	}
+	if *stddev <= 0 {
+		panic("The stddev must be positive")
+	}
+	if *sampleInterval <= 0 {
+		panic("The sample interval must be positive")
+	}
+	if *threshold == 0 {
+		*threshold = 3 * *stddev
+	}
+	if *amplification == 0 {
+		*amplification = 1 / *stddev
+	}
+
+	algorithmNames := strings.Split(*algorithms, ",")
+	phasedAlgorithms := make([]algorithm.PhasedAlgorithm[geometry.Vector], len(algorithmNames))
+	for i, name := range algorithmNames {
+		phasedAlgorithms[i] = algorithm.AlgorithmStringToPhasedFunc[geometry.Vector](name)
+	}
+	calc := partitioning3D.CostCalculator{Threshold: *threshold, Amplification: *amplification}
+	noise := utils.NormalDist{Mean: 0, Stddev: *stddev}
+
+	var results []BenchmarkResult
+	for _, planes := range parseInts(*numberOfPlanes, "number of planes") {
+		for _, points := range parseInts(*pointsPerPlane, "number of points per plane") {
+			for repetition := 0; repetition < *repetitions; repetition++ {
+				dataSeed := utils.DeriveSeed(*seed, planes, points, repetition)
+				testData := evaluation.GenerateDataWithNoiseWithSource(rand.New(rand.NewSource(dataSeed)), planes, points, noise)
+
+				for i, phasedAlgorithm := range phasedAlgorithms {
+					result := run(phasedAlgorithm, &testData, calc, *sampleInterval)
+					result.Algorithm = algorithmNames[i]
+					result.NumberOfPlanes = planes
+					result.PointsPerPlane = points
+					result.Repetition = repetition
+					result.Seed = dataSeed
+					if *verbose >= 1 {
+						printResult(result)
+					}
+					results = append(results, result)
+				}
+			}
+		}
+	}
+
+	if strings.HasSuffix(*output, ".json") {
+		writeJson(*output, results)
+	} else {
+		writeCsv(*output, results)
+	}
+}
+
+// Parses a comma separated list of positive integers
+func parseInts(list, name string) []int {
+	var values []int
+	for _, field := range strings.Split(list, ",") {
+		value, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || value < 1 {
+			panic(fmt.Sprintf("The %s must be positive integers, got %s", name, field))
+		}
+		values = append(values, value)
+	}
+	return values
+}
+
+// Runs the algorithm on the test data and measures its initialization and its search
+func run(phasedAlgorithm algorithm.PhasedAlgorithm[geometry.Vector], testData *evaluation.TestData,
+	calc partitioning3D.CostCalculator, sampleInterval time.Duration) BenchmarkResult {
+	var search func() algorithm.PartitioningArray
+	var partitioning algorithm.PartitioningArray
+
+	initialization := measure(func() { search = phasedAlgorithm(&testData.Points, calc) }, sampleInterval)
+	searchMeasurement := measure(func() { partitioning = search() }, sampleInterval)
+
+	return BenchmarkResult{
+		Accuracy:       evaluation.EvaluatePartitioning(partitioning, testData).Accuracy,
+		Initialization: initialization,
+		Search:         searchMeasurement,
+	}
+}
+
+// Measures the time, the allocations and the peak of the heap of the phase. The garbage collector
+// runs before the phase, s.t. the heap only contains live objects when the phase starts.
+func measure(phase func(), sampleInterval time.Duration) PhaseMeasurement {
+	runtime.GC()
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	baseline := heapObjects()
+
+	sampler := startHeapSampler(sampleInterval)
+	start := time.Now()
+	phase()
+	elapsed := time.Since(start)
+	peak := sampler.stop()
+	runtime.ReadMemStats(&after)
+
+	measurement := PhaseMeasurement{
+		Time:           float64(elapsed.Microseconds()) / 1000,
+		Allocations:    after.Mallocs - before.Mallocs,
+		AllocatedBytes: after.TotalAlloc - before.TotalAlloc,
+	}
+	if peak > baseline {
+		measurement.PeakHeapBytes = peak - baseline
+	}
+	return measurement
+}
+
+// Returns the bytes of the objects on the heap, without stopping the world
+func heapObjects() uint64 {
+	samples := []metrics.Sample{{Name: heapObjectsMetric}}
+	metrics.Read(samples)
+	return samples[0].Value.Uint64()
+}
+
+// Samples the heap in the background and keeps track of its peak
+type heapSampler struct {
+	done chan struct{}
+	peak chan uint64
+}
+
+func startHeapSampler(interval time.Duration) heapSampler {
+	sampler := heapSampler{done: make(chan struct{}), peak: make(chan uint64)}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		peak := uint64(0)
+		for {
+			if heap := heapObjects(); heap > peak {
+				peak = heap
+			}
+			select {
+			case <-sampler.done:
+				if heap := heapObjects(); heap > peak {
+					peak = heap
+				}
+				sampler.peak <- peak
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return sampler
+}
+
+// Stops the sampling and returns the peak of the heap
+func (sampler heapSampler) stop() uint64 {
+	close(sampler.done)
+	return <-sampler.peak
+}
+
+func printResult(result BenchmarkResult) {
+	fmt.Printf("%s, planes: %d, points per plane: %d, repetition: %d, accuracy: %f%%\n",
+		result.Algorithm, result.NumberOfPlanes, result.PointsPerPlane, result.Repetition, result.Accuracy*100)
+	for _, phase := range []struct {
+		name        string
+		measurement PhaseMeasurement
+	}{{"initialization", result.Initialization}, {"search", result.Search}} {
+		fmt.Printf("  %s: %.3fms, %d allocations, %d bytes allocated, peak heap %d bytes\n", phase.name,
+			phase.measurement.Time, phase.measurement.Allocations, phase.measurement.AllocatedBytes, phase.measurement.PeakHeapBytes)
+	}
+}
+
+func writeJson(path string, results []BenchmarkResult) {
+	jsonString, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	if err := ioutil.WriteFile(path, jsonString, 0644); err != nil {
+		panic(err)
+	}
+}
+
+func writeCsv(path string, results []BenchmarkResult) {
+	file, err := os.Create(path)
+	if err != nil {
+		panic(err)
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+
+	header := []string{"algorithm", "numberOfPlanes", "pointsPerPlane", "repetition", "seed", "accuracy"}
+	for _, phase := range []string{"initialization", "search"} {
+		header = append(header, phase+"Time", phase+"Allocations", phase+"AllocatedBytes", phase+"PeakHeapBytes")
+	}
+	writer.Write(header)
+	for _, result := range results {
+		row := []string{
+			result.Algorithm,
+			strconv.Itoa(result.NumberOfPlanes),
+			strconv.Itoa(result.PointsPerPlane),
+			strconv.Itoa(result.Repetition),
+			strconv.FormatInt(result.Seed, 10),
+			formatFloat(result.Accuracy),
+		}
+		for _, measurement := range []PhaseMeasurement{result.Initialization, result.Search} {
+			row = append(row,
+				formatFloat(measurement.Time),
+				strconv.FormatUint(measurement.Allocations, 10),
+				strconv.FormatUint(measurement.AllocatedBytes, 10),
+				strconv.FormatUint(measurement.PeakHeapBytes, 10),
+			)
+		}
+		writer.Write(row)
+	}
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,286 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/algorithm"
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/geometry"
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/partitioning3D"
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/partitioning3D/evaluation"
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/utils"
+	"github.com/go-playground/validator/v10"
+)
+
+// The configuration of a sweep. In grid mode every combination of the thresholds and amplifications
+// is evaluated. In random mode the thresholds and amplifications must contain exactly two values,
+// the bounds between which `Samples` settings are sampled log-uniformly. If `Relative` is true, the
+// thresholds are multiplied with the stddev and the amplifications are divided by it.
+type SweepConfig struct {
+	Mode           string    `validate:"required,oneof=grid random"`
+	Thresholds     []float64 `validate:"required,dive,gt=0"`
+	Amplifications []float64 `validate:"required,dive,gt=0"`
+	Samples        int       `validate:"required_if=Mode random,gte=0"`
+	Relative       bool
+	StddevValues   []float64 `validate:"omitempty,dive,gt=0"`
+	NumberOfPlanes []int     `validate:"omitempty,dive,gt=0"`
+	PointsPerPlane int       `validate:"gte=0"`
+	Iterations     int       `validate:"gte=0"` // How many data sets are generated for every stddev and number of planes
+	Seed           int64
+}
+
+// The result of one run of the algorithm. Accuracy and NumOfPlanesError are only known for generated data.
+type SweepResult struct {
+	Threshold        float64
+	Amplification    float64
+	Stddev           float64
+	NumberOfPlanes   int
+	Iteration        int
+	Accuracy         *float64 `json:",omitempty"`
+	NumOfPlanesError *float64 `json:",omitempty"`
+	NumOfPartitions  int
+	Objective        float64
+	Time             float64 // in milliseconds
+}
+
+// A data set on which every setting is evaluated
+type dataSet struct {
+	stddev         float64
+	numberOfPlanes int
+	iteration      int
+	testData       *evaluation.TestData // nil if the data was read from a csv file
+	points         *[]geometry.Vector
+}
+
+func main() {
+	selectedAlgorithm := flag.String("algorithm", "", "The algorithm which should be used for the partitioning")
+	configFile := flag.String("config", "", "The path to the json configuration file of the sweep")
+	inputFile := flag.String("inputFile", "", "If specified, the points are read from this csv file instead of being generated")
+	output := flag.String("output", "", "Where the results should be written to, the format depends on the extension (.csv or .json)")
+	verbose := flag.Int("verbose", 0, "0: nothing will be printed, 1: every result will be printed")
+	flag.Parse()
+
+	if !strings.HasSuffix(*output, ".csv") && !strings.HasSuffix(*output, ".json") {
+		panic("The output file must be a csv or json file")
+	}
+	config := loadConfig(*configFile, *inputFile != "")
+	partitioningAlgorithm := algorithm.AlgorithmStringToFunc[geometry.Vector](*selectedAlgorithm)
+	random := rand.New(rand.NewSource(config.Seed))
+
+	settings := createSettings(config, random)
+	dataSets := createDataSets(config, *inputFile, random)
+
+	results := make([]SweepResult, 0, len(settings)*len(dataSets))
+	for _, data := range dataSets {
+		for _, setting := range settings {
+			result := run(partitioningAlgorithm, setting, data, config.Relative)
+			if *verbose >= 1 {
+				printResult(result)
+			}
+			results = append(results, result)
+		}
+	}
+
+	if strings.HasSuffix(*output, ".json") {
+		writeJson(*output, results)
+	} else {
+		writeCsv(*output, results)
+	}
+}
+
+// This function loads the config in the given file and sets the defaults for the missing values. If the
+// points are read from a file, the stddev has no default, because it's a property of the given data.
+func loadConfig(filePath string, fromFile bool) SweepConfig {
+	file, err := os.Open(filePath)
+	if err != nil {
+		panic(err)
+	}
+	defer file.Close()
+	fileContent, err := ioutil.ReadAll(file)
+	if err != nil {
+		panic(err)
+	}
+
+	var config SweepConfig
+	if err := json.Unmarshal(fileContent, &config); err != nil {
+		panic(err)
+	}
+	if err := validator.New().Struct(config); err != nil {
+		panic(fmt.Sprintf("Config file is invalid: %s", err))
+	}
+	if config.Mode == "random" && (len(config.Thresholds) != 2 || len(config.Amplifications) != 2) {
+		panic("In random mode the thresholds and amplifications must be the lower and upper bound")
+	}
+
+	if fromFile && config.Relative && len(config.StddevValues) != 1 {
+		panic("Relative settings for data from a file need exactly one value in StddevValues, the stddev of the data")
+	}
+	if !fromFile && len(config.StddevValues) == 0 {
+		config.StddevValues = []float64{0.01}
+	}
+	if len(config.NumberOfPlanes) == 0 {
+		config.NumberOfPlanes = []int{3}
+	}
+	if config.PointsPerPlane == 0 {
+		config.PointsPerPlane = 20
+	}
+	if config.Iterations == 0 {
+		config.Iterations = 1
+	}
+	return config
+}
+
+// Returns the (threshold, amplification) pairs that are evaluated
+func createSettings(config SweepConfig, random *rand.Rand) [][2]float64 {
+	var settings [][2]float64
+	if config.Mode == "grid" {
+		for _, threshold := range config.Thresholds {
+			for _, amplification := range config.Amplifications {
+				settings = append(settings, [2]float64{threshold, amplification})
+			}
+		}
+		return settings
+	}
+	for i := 0; i < config.Samples; i++ {
+		settings = append(settings, [2]float64{
+			logUniform(random, config.Thresholds[0], config.Thresholds[1]),
+			logUniform(random, config.Amplifications[0], config.Amplifications[1]),
+		})
+	}
+	return settings
+}
+
+// Samples a value between min and max s.t. its logarithm is uniformly distributed
+func logUniform(random *rand.Rand, min, max float64) float64 {
+	return math.Exp(math.Log(min) + random.Float64()*(math.Log(max)-math.Log(min)))
+}
+
+// Generates the data sets with the given source or reads the data set from the input file
+func createDataSets(config SweepConfig, inputFile string, random *rand.Rand) []dataSet {
+	if inputFile != "" {
+		points, err := partitioning3D.ParsePoints(inputFile)
+		if err != nil {
+			panic(err)
+		}
+		// the stddev of csv data is only known if it's given for relative settings
+		stddev := 0.0
+		if config.Relative {
+			stddev = config.StddevValues[0]
+		}
+		return []dataSet{{stddev: stddev, points: points}}
+	}
+
+	var dataSets []dataSet
+	for _, stddev := range config.StddevValues {
+		for _, numberOfPlanes := range config.NumberOfPlanes {
+			for i := 0; i < config.Iterations; i++ {
+				testData := evaluation.GenerateDataWithNoiseWithSource(random, numberOfPlanes, config.PointsPerPlane, utils.NormalDist{Mean: 0, Stddev: stddev})
+				dataSets = append(dataSets, dataSet{
+					stddev:         stddev,
+					numberOfPlanes: numberOfPlanes,
+					iteration:      i,
+					testData:       &testData,
+					points:         &testData.Points,
+				})
+			}
+		}
+	}
+	return dataSets
+}
+
+// Runs the algorithm with the given setting on the given data set
+func run(partitioningAlgorithm algorithm.PartitioningAlgorithm[geometry.Vector], setting [2]float64, data dataSet, relative bool) SweepResult {
+	threshold, amplification := setting[0], setting[1]
+	if relative {
+		threshold *= data.stddev
+		amplification /= data.stddev
+	}
+	calc := partitioning3D.CostCalculator{Threshold: threshold, Amplification: amplification}
+
+	start := time.Now()
+	partitioning := partitioningAlgorithm(data.points, calc)
+	elapsed := float64(time.Since(start).Microseconds()) / 1000
+
+	result := SweepResult{
+		Threshold:       threshold,
+		Amplification:   amplification,
+		Stddev:          data.stddev,
+		NumberOfPlanes:  data.numberOfPlanes,
+		Iteration:       data.iteration,
+		NumOfPartitions: len(utils.ToSet(partitioning)),
+		Objective:       algorithm.Objective[geometry.Vector](data.points, calc, partitioning),
+		Time:            elapsed,
+	}
+	if data.testData != nil {
+		eval := evaluation.EvaluatePartitioning(partitioning, data.testData)
+		result.Accuracy = &eval.Accuracy
+		result.NumOfPlanesError = &eval.NumOfPlanesError
+	}
+	return result
+}
+
+func printResult(result SweepResult) {
+	fmt.Printf("threshold: %f, amplification: %f, stddev: %f, planes: %d, iteration: %d, partitions: %d, objective: %f, time: %fms",
+		result.Threshold, result.Amplification, result.Stddev, result.NumberOfPlanes, result.Iteration,
+		result.NumOfPartitions, result.Objective, result.Time)
+	if result.Accuracy != nil {
+		fmt.Printf(", accuracy: %f%%, number of planes error: %f%%", *result.Accuracy*100, *result.NumOfPlanesError*100)
+	}
+	fmt.Println()
+}
+
+func writeJson(path string, results []SweepResult) {
+	jsonString, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	if err := ioutil.WriteFile(path, jsonString, 0644); err != nil {
+		panic(err)
+	}
+}
+
+func writeCsv(path string, results []SweepResult) {
+	file, err := os.Create(path)
+	if err != nil {
+		panic(err)
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+
+	writer.Write([]string{"threshold", "amplification", "stddev", "numberOfPlanes", "iteration", "accuracy",
+		"numOfPlanesError", "numOfPartitions", "objective", "time"})
+	for _, result := range results {
+		accuracy, numOfPlanesError := "", ""
+		if result.Accuracy != nil {
+			accuracy = formatFloat(*result.Accuracy)
+			numOfPlanesError = formatFloat(*result.NumOfPlanesError)
+		}
+		writer.Write([]string{
+			formatFloat(result.Threshold),
+			formatFloat(result.Amplification),
+			formatFloat(result.Stddev),
+			strconv.Itoa(result.NumberOfPlanes),
+			strconv.Itoa(result.Iteration),
+			accuracy,
+			numOfPlanesError,
+			strconv.Itoa(result.NumOfPartitions),
+			formatFloat(result.Objective),
+			formatFloat(result.Time),
+		})
+	}
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -3,12 +3,16 @@ package main
 import (
 	"flag"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
 	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/algorithm"
 	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/geometry"
 	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/partitioning3D"
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/partitioning3D/evaluation"
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/partitioningLines"
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/partitioningSubspaces"
 	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/utils"
 )
 
@@ -18,6 +22,34 @@ func main() {
 	amplification := flag.Float64("amplification", 1.0, "The amplification for the cost calculation")
 	selectedAlgorithm := flag.String("algorithm", "", "The algorithm which should be used for the partitioning")
 	constraintFile := flag.String("constraintFile", "", "The path to a file which constraints constraints for a partitioning")
+	workers := flag.Int("workers", 0, "The number of workers GreedyMoving uses to update its costs, if not positive GOMAXPROCS is used")
+	cacheSize := flag.Int("cacheSize", 0, "If positive, at most this many triple costs are cached and reused by the algorithm")
+	costShape := flag.String("costShape", "linear", "How the residual is transformed into costs: linear, logistic, hinge or truncatedQuadratic")
+	residual := flag.String("residual", "max", "How the distances of the points to the plane are combined: max, rms or sumOfSquares")
+	costScale := flag.Float64("costScale", 0, "The scale of the logistic, hinge and truncated quadratic cost shape, if 0 the threshold is used")
+	autoCalibrate := flag.Bool("autoCalibrate", false, `If true the costs are calibrated from the noise model given by noiseMean and noiseStddev
+		instead of using threshold and amplification`)
+	noiseMean := flag.Float64("noiseMean", 0, "The mean of the noise for the calibration of the costs")
+	noiseStddev := flag.Float64("noiseStddev", 0.01, "The standard deviation of the noise for the calibration of the costs")
+	expectedPlanes := flag.Int("expectedPlanes", partitioning3D.DefaultCalibrationParameters.ExpectedPlanes, "How many planes are expected in the data for the calibration of the costs")
+	priorWeight := flag.Float64("priorWeight", 0, "How much the prior on the number of planes is added to the calibrated costs, between 0 and 1")
+	lines := flag.Bool("lines", false, "If true the points are partitioned into lines through the origin instead of planes, 2D points are supported as well")
+	affine := flag.Bool("affine", false, "If true the points are partitioned into planes that don't have to go through the origin")
+	neighbors := flag.Int("neighbors", 5, "How many nearest neighbors the cost calculation for affine planes uses")
+	affineMode := flag.String("affineMode", string(partitioning3D.QuadrupleMode), "How the affine costs are computed from the neighbors, one of quadruples or neighborFit")
+	sampleBudget := flag.Int("sampleBudget", algorithm.DefaultSamplingParameters.Budget, "How many triples SampledGreedy samples at most per join or move")
+	sampleCandidates := flag.Int("sampleCandidates", algorithm.DefaultSamplingParameters.Candidates, "How many join partners SampledGreedy samples for every element")
+	sampleSeed := flag.Int64("sampleSeed", algorithm.DefaultSamplingParameters.Seed, "The seed for the sampling of SampledGreedy")
+	refine := flag.Bool("refine", false, `If true the planes are refitted to the partitions and every point is reassigned to its nearest plane
+		after the partitioning, only for points on planes through the origin`)
+	outlierDistance := flag.Float64("outlierDistance", 0, "Points which are farther away from every plane are outliers (label -1) after the refinement, if 0 there are no outliers")
+	minClusterSize := flag.Int("minClusterSize", partitioning3D.DefaultRefinementParameters.MinClusterSize, "Partitions with fewer points are dissolved by the refinement")
+	refineIterations := flag.Int("refineIterations", partitioning3D.DefaultRefinementParameters.MaxIterations, "How often the refinement refits the planes at most")
+	noiseMinSize := flag.Int("noiseMinSize", 0, "Partitions with fewer points are labeled as noise (label -1) after the partitioning")
+	evaluate := flag.Bool("evaluate", false, `If true the csv must have a column 'label' with the ground truth label of every point (-1 for noise)
+		and the partitioning is evaluated against these labels, only for points on planes`)
+	subspaceDimension := flag.Int("subspaceDimension", 0, `If positive, the points are partitioned into linear subspaces with this dimension (1 or 2).
+		Every column of the csv is then a coordinate of the points`)
 
 	flag.Parse()
 
@@ -27,28 +59,169 @@ func main() {
 		panic("Input file must be a csv file!")
 	}
 
-	points, err := partitioning3D.ParsePoints(*fileName)
+	algorithmOptions := options{
+		selectedAlgorithm: *selectedAlgorithm,
+		constraintFile:    *constraintFile,
+		workers:           *workers,
+		cacheSize:         *cacheSize,
+		sampling:          algorithm.SamplingParameters{Budget: *sampleBudget, Candidates: *sampleCandidates, Seed: *sampleSeed},
+	}
+
+	if *autoCalibrate && (*lines || *affine || *subspaceDimension > 0) {
+		panic("The costs can only be calibrated for planes through the origin")
+	}
+	if *autoCalibrate && (*costShape != string(partitioning3D.Linear) || *residual != string(partitioning3D.MaxDistance) || *costScale != 0) {
+		panic("The calibration determines the costs from the maximum distance, so costShape, residual and costScale can't be used with autoCalibrate")
+	}
+	if (*lines || *affine || *subspaceDimension > 0) &&
+		(*costShape != string(partitioning3D.Linear) || *residual != string(partitioning3D.MaxDistance) || *costScale != 0) {
+		panic("costShape, residual and costScale are only available for planes through the origin")
+	}
+	if (*lines || *affine || *subspaceDimension > 0) && (*refine || *outlierDistance != 0 ||
+		*minClusterSize != partitioning3D.DefaultRefinementParameters.MinClusterSize ||
+		*refineIterations != partitioning3D.DefaultRefinementParameters.MaxIterations) {
+		panic("The refinement is only available for planes through the origin")
+	}
+	if *evaluate && *subspaceDimension > 0 {
+		panic("Only partitionings into planes can be evaluated")
+	}
+	if *subspaceDimension > 2 {
+		panic("The dimension of the subspaces must be 1 or 2, three points are always in a common subspace with 3 dimensions")
+	}
+	if *subspaceDimension > 0 {
+		points, err := partitioningSubspaces.ParsePoints(*fileName)
+		if err != nil {
+			panic(err)
+		}
+		calc := &partitioningSubspaces.CostCalculator{Dimension: *subspaceDimension, Threshold: *threshold, Amplification: *amplification}
+		partitioningArray := algorithm.LabelNoise(partition[geometry.VectorN](points, calc, algorithmOptions), *noiseMinSize)
+		printPartitioning(points, partitioningArray, func(point geometry.VectorN) string {
+			return fmt.Sprint([]float64(point))
+		})
+		return
+	}
+
+	var points *[]geometry.Vector
+	var testData evaluation.TestData
+	var err error
+	if *evaluate {
+		if *lines {
+			panic("Only partitionings into planes can be evaluated")
+		}
+		testData, err = evaluation.LoadLabeledTestData(*fileName, *affine)
+		points = &testData.Points
+	} else if *lines {
+		points, err = partitioningLines.ParsePoints(*fileName)
+	} else {
+		points, err = partitioning3D.ParsePoints(*fileName)
+	}
 
 	if err != nil {
 		panic(err)
 	}
 
-	calc := partitioning3D.CostCalculator{Threshold: *threshold, Amplification: *amplification}
+	var calc algorithm.CostCalculator[geometry.Vector]
+	if *lines {
+		calc = &partitioningLines.CostCalculator{Threshold: *threshold, Amplification: *amplification}
+	} else if *autoCalibrate {
+		calibration := partitioning3D.Calibrate(partitioning3D.CalibrationParameters{
+			Noise:          utils.NormalDist{Mean: *noiseMean, Stddev: *noiseStddev},
+			ExpectedPlanes: *expectedPlanes,
+			PriorWeight:    *priorWeight,
+		})
+		fmt.Printf("Calibrated costs, approximately threshold: %f, amplification: %f\n", calibration.Threshold, calibration.Amplification)
+		calc = calibration.Calculator
+	} else if *affine {
+		mode, err := partitioning3D.ParseAffineMode(*affineMode)
+		if err != nil {
+			panic(err)
+		}
+		affineCalc := partitioning3D.CreateAffineCostCalculator(points, *threshold, *amplification, *neighbors)
+		affineCalc.Mode = mode
+		calc = affineCalc
+	} else {
+		shape, err := partitioning3D.ParseCostShape(*costShape)
+		if err != nil {
+			panic(err)
+		}
+		if err := shape.ValidateScale(*threshold, *costScale); err != nil {
+			panic(err)
+		}
+		residual, err := partitioning3D.ParseResidual(*residual)
+		if err != nil {
+			panic(err)
+		}
+		calc = &partitioning3D.CostCalculator{Threshold: *threshold, Amplification: *amplification, Shape: shape, Residual: residual, Scale: *costScale}
+	}
+
+	partitioningArray := partition[geometry.Vector](points, calc, algorithmOptions)
+	if *refine {
+		partitioningArray = partitioning3D.Refine(points, partitioningArray, partitioning3D.RefinementParameters{
+			MaxIterations:   *refineIterations,
+			OutlierDistance: *outlierDistance,
+			MinClusterSize:  *minClusterSize,
+		})
+	}
+	partitioningArray = algorithm.LabelNoise(partitioningArray, *noiseMinSize)
+	printPartitioning(points, partitioningArray, func(point geometry.Vector) string {
+		return fmt.Sprintf("X: %f, Y: %f, Z: %f", point.X, point.Y, point.Z)
+	})
+
+	if *evaluate {
+		eval := evaluation.EvaluatePartitioning(partitioningArray, &testData)
+		fmt.Printf("Evaluation against the %d labeled planes with %v points per plane:\n", testData.NumOfPlanes, testData.PlaneSizes())
+		eval.Report(os.Stdout)
+	}
+}
 
-	var partitioningArray algorithm.PartitioningArray
+// The command-line arguments which select and configure the partitioning algorithm
+type options struct {
+	selectedAlgorithm string
+	constraintFile    string
+	workers           int
+	cacheSize         int
+	sampling          algorithm.SamplingParameters
+}
 
-	start := time.Now()
-	if *constraintFile != "" && *selectedAlgorithm == "GreedyMoving" {
-		partitioningArray = algorithm.GreedyMovingWithConstraints[geometry.Vector](points, &calc, *constraintFile)
+// Partitions the given points with the algorithm that is selected in the options and prints how long it took
+func partition[data any](points *[]data, calc algorithm.CostCalculator[data], options options) algorithm.PartitioningArray {
+	var partitioningAlgorithm algorithm.PartitioningAlgorithm[data]
+	if options.constraintFile != "" && options.selectedAlgorithm == "GreedyMoving" {
+		partitioningAlgorithm = func(input *[]data, calc algorithm.CostCalculator[data]) algorithm.PartitioningArray {
+			return algorithm.GreedyMovingWithConstraints[data](input, calc, options.constraintFile)
+		}
+	} else if options.selectedAlgorithm == "GreedyMoving" {
+		partitioningAlgorithm = algorithm.GreedyMovingWithWorkers[data](options.workers)
+	} else if options.selectedAlgorithm == "SampledGreedy" {
+		partitioningAlgorithm = algorithm.SampledGreedy[data](options.sampling)
 	} else {
-		partitioningArray = algorithm.AlgorithmStringToFunc[geometry.Vector](*selectedAlgorithm)(points, &calc)
+		partitioningAlgorithm = algorithm.AlgorithmStringToFunc[data](options.selectedAlgorithm)
+	}
+	if options.cacheSize > 0 {
+		partitioningAlgorithm = algorithm.WithCache(partitioningAlgorithm, options.cacheSize, func(stats algorithm.CacheStatistics) {
+			fmt.Printf("Cache hits: %d, cache misses: %d, hit rate: %.2f%%\n", stats.Hits, stats.Misses, stats.HitRate()*100)
+		})
 	}
+
+	start := time.Now()
+	partitioningArray := partitioningAlgorithm(points, calc)
 	fmt.Printf("Finished partitioning after %dms\n", time.Since(start).Milliseconds())
+	return partitioningArray
+}
+
+// Prints the partitioning array and the points of every partition with the given format.
+// The points which are labeled as noise are printed last.
+func printPartitioning[data any](points *[]data, partitioningArray algorithm.PartitioningArray, format func(data) string) {
 	fmt.Println("Partitioning array:", partitioningArray)
 
 	// Order elements by their partition
 	partitioning := make(map[int]*utils.LinkedList[int])
+	noise := utils.LinkedList[int]{}
 	for i, partition := range partitioningArray {
+		if partition == algorithm.NoiseLabel {
+			noise.Add(i)
+			continue
+		}
 		val, ok := partitioning[partition]
 		if ok {
 			val.Add(i)
@@ -66,10 +239,17 @@ func main() {
 		fmt.Printf("Partition_%d\n", i)
 		iterator := elements.Iterator()
 		for iterator.HasNext() {
-			point := iterator.Next()
-			fmt.Printf("X: %f, Y: %f, Z: %f\n", (*points)[point].X, (*points)[point].Y, (*points)[point].Z)
+			fmt.Println(format((*points)[iterator.Next()]))
 		}
 		fmt.Println("--------------")
 		i++
 	}
+	if noise.Length() > 0 {
+		fmt.Println("Noise")
+		iterator := noise.Iterator()
+		for iterator.HasNext() {
+			fmt.Println(format((*points)[iterator.Next()]))
+		}
+		fmt.Println("--------------")
+	}
 }
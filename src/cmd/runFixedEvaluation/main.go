@@ -6,6 +6,7 @@ import (
 	"flag"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"math/rand"
 	"os"
 	"os/exec"
@@ -27,25 +28,171 @@ var config EvalConfig
 var bar = mpb.New()
 
 type EvalConfig struct {
-	Iterations     int       `validate:"required,gt=0"`
-	StddevValues   []float64 `validate:"required,dive,gt=0"`
-	PointsPerPlane int       `validate:"required,gt=0"`
+	Algorithms      []string  `validate:"dive,required"` // The algorithms which are evaluated on the same data, the command-line argument overrides them
+	Iterations      int       `validate:"required,gt=0"`
+	StddevValues    []float64 `validate:"required,dive,gt=0"`
+	PointsPerPlane  int       `validate:"required,gt=0"`
+	CostShape       string    `validate:"omitempty,oneof=linear logistic hinge truncatedQuadratic"`
+	Residual        string    `validate:"omitempty,oneof=max rms sumOfSquares"`
+	CostScale       float64   `validate:"gte=0"` // The scale of the cost shape as a multiple of the stddev
+	Refine          bool      // Whether the partitionings are refined by refitting planes and reassigning points
+	OutlierDistance float64   `validate:"gte=0"` // The outlier distance of the refinement as a multiple of the stddev
+	MinClusterSize  int       `validate:"gte=0"` // The minimum cluster size of the refinement
+	PrecomputeCosts bool      // Whether all triple costs are computed once per iteration and shared by the algorithms
+
+	// The normals of the planes from which the points are sampled. If empty and NumberOfPlanes
+	// is 0 the XY, XZ and YZ planes are used.
+	Planes           []geometry.Vector
+	NumberOfPlanes   int     `validate:"gte=0"` // If positive this many random planes are created in every iteration, can't be used together with Planes
+	NoiseMean        float64 // The mean of the noise along the normal as a multiple of the stddev
+	DegreesOfFreedom float64 `validate:"gte=0"`      // If positive the noise along the normal is Student-t distributed
+	TangentialNoise  float64 `validate:"gte=0"`      // The stddev of the noise within the planes as a multiple of the stddev
+	PatchRadius      float64 `validate:"gte=0"`      // If positive the points are sampled from patches with this radius
+	OutlierFraction  float64 `validate:"gte=0,lt=1"` // The fraction of all points which are uniformly distributed outliers
+
+	// The threshold and amplification of the cost calculator. If nil the threshold is the residual of
+	// three points with a distance of 3 stddev and the amplification is 1/stddev.
+	Threshold     *CostParameter
+	Amplification *CostParameter
+}
+
+// A cost parameter which depends on the stddev, its value is Factor * stddev^Exponent. So if the
+// exponent is 0 the value is fixed.
+type CostParameter struct {
+	Factor   float64
+	Exponent float64
+}
+
+// Returns the value of the cost parameter for the given stddev
+func (parameter *CostParameter) value(stddev float64) float64 {
+	return parameter.Factor * math.Pow(stddev, parameter.Exponent)
+}
+
+// Returns whether both cost parameters are nil or have the same values
+func equalCostParameters(p1, p2 *CostParameter) bool {
+	if p1 == nil || p2 == nil {
+		return p1 == p2
+	}
+	return *p1 == *p2
 }
 
 type Result struct {
-	GitCommit       string
-	Algorithm       string
-	Iterations      int
-	StddevValues    []float64
-	PointsPerPlane  int
-	Seed            int64
-	AccuracyResults []AccuracyResult
-	outputFile      *os.File
+	GitCommit        string
+	Algorithms       []string
+	Iterations       int
+	StddevValues     []float64
+	PointsPerPlane   int
+	CostShape        string
+	Residual         string
+	CostScale        float64
+	Refine           bool
+	OutlierDistance  float64
+	MinClusterSize   int
+	PrecomputeCosts  bool
+	Planes           []geometry.Vector
+	NumberOfPlanes   int
+	NoiseMean        float64
+	DegreesOfFreedom float64
+	TangentialNoise  float64
+	PatchRadius      float64
+	OutlierFraction  float64
+	Threshold        *CostParameter
+	Amplification    *CostParameter
+	Seed             int64            // The base seed from which the seeds of the iterations are derived
+	Workers          int              // How many iterations were evaluated concurrently when the evaluation was (last) run
+	AccuracyResults  []AccuracyResult // The results for every stddev value
+	outputPath       string
 }
 
+// The results of all algorithms for one stddev value
 type AccuracyResult struct {
-	Accuracies []float64
-	Time       int64
+	Seeds               []int64           // The seed from which the data of every iteration was generated
+	GenerationTimes     []float64         // The time in ms which the generation of the data took in every iteration
+	PrecomputationTimes []float64         // The time in ms which the computation of all triple costs took in every iteration, only if the costs are precomputed
+	Algorithms          []AlgorithmResult // The results in the order of the algorithms in the result
+}
+
+// The results of one algorithm for one stddev value. In iteration i all algorithms partitioned
+// the same data, so the results at index i of different algorithms can be compared pairwise.
+type AlgorithmResult struct {
+	Accuracies          []float64
+	Time                float64   // The sum of the search times in ms
+	InitializationTimes []float64 // The time in ms which the initialization of the algorithm took in every iteration
+	Times               []float64 // The time in ms which the search of the algorithm (including the refinement) took in every iteration
+	Objectives          []float64
+	Metrics             []Metrics
+}
+
+// One iteration of the evaluation given by the index of the stddev value and of the iteration
+type iterationTask struct {
+	stddevIndex, iteration int
+}
+
+// The results of all algorithms in one iteration
+type iterationResult struct {
+	iterationTask
+	seed               int64
+	generationTime     float64
+	precomputationTime float64
+	runs               []algorithmRun // The runs in the order of the algorithms
+	err                any            // The value of the panic if the iteration panicked
+}
+
+// The result of one algorithm in one iteration
+type algorithmRun struct {
+	accuracy           float64
+	initializationTime float64
+	time               float64
+	objective          float64
+	metrics            Metrics
+}
+
+// The metrics of one partitioning besides the accuracy
+type Metrics struct {
+	NumOfPlanesError  float64
+	AdjustedRandIndex float64
+	VI                float64
+	NMI               float64
+	MeanAngle         float64
+}
+
+// Appends empty results for the next stddev value
+func (result *Result) addAccuracyResult() {
+	accuracyResult := AccuracyResult{
+		Seeds:      make([]int64, 0, config.Iterations),
+		Algorithms: make([]AlgorithmResult, len(result.Algorithms)),
+	}
+	for i := range accuracyResult.Algorithms {
+		accuracyResult.Algorithms[i] = AlgorithmResult{Accuracies: make([]float64, 0, config.Iterations)}
+	}
+	result.AccuracyResults = append(result.AccuracyResults, accuracyResult)
+}
+
+// Appends the results of the given iteration, which must be the next iteration of the result
+func (result *Result) addIteration(iteration iterationResult) {
+	if iteration.stddevIndex == len(result.AccuracyResults) {
+		result.addAccuracyResult()
+	}
+	accuracyResult := &result.AccuracyResults[iteration.stddevIndex]
+	accuracyResult.Seeds = append(accuracyResult.Seeds, iteration.seed)
+	accuracyResult.GenerationTimes = append(accuracyResult.GenerationTimes, iteration.generationTime)
+	if config.PrecomputeCosts {
+		accuracyResult.PrecomputationTimes = append(accuracyResult.PrecomputationTimes, iteration.precomputationTime)
+	}
+	for i, run := range iteration.runs {
+		algorithmResult := &accuracyResult.Algorithms[i]
+		algorithmResult.Accuracies = append(algorithmResult.Accuracies, run.accuracy)
+		algorithmResult.Time += run.time
+		algorithmResult.InitializationTimes = append(algorithmResult.InitializationTimes, run.initializationTime)
+		algorithmResult.Times = append(algorithmResult.Times, run.time)
+		algorithmResult.Objectives = append(algorithmResult.Objectives, run.objective)
+		algorithmResult.Metrics = append(algorithmResult.Metrics, run.metrics)
+	}
+}
+
+// Returns how many iterations were completed for the stddev value with the given index
+func (result *Result) completedIterations(stddevIndex int) int {
+	return len(result.AccuracyResults[stddevIndex].Algorithms[0].Accuracies)
 }
 
 func (result *Result) write() {
@@ -53,23 +200,62 @@ func (result *Result) write() {
 	if err != nil {
 		panic(fmt.Sprintf("Cannot transform go struct to json for the following go struct: %#v", result))
 	}
-	ioutil.WriteFile(result.outputFile.Name(), jsonString, os.ModePerm)
+	// write to a temporary file first, s.t. the output file is never partially written
+	temporaryPath := result.outputPath + ".tmp"
+	if err := ioutil.WriteFile(temporaryPath, jsonString, os.ModePerm); err != nil {
+		panic(fmt.Sprintf("Could not write the result: %v", err))
+	}
+	if err := os.Rename(temporaryPath, result.outputPath); err != nil {
+		panic(fmt.Sprintf("Could not write the result: %v", err))
+	}
 }
 
 // This function checks whether the parameters stored in the result struct
 // coincide with the specified parameters in this file. If not the function panics.
 // This can be used if the evaluation is continued from an existing evaluation.
-func (result *Result) checkParameters(algorithm, commit string) {
+func (result *Result) checkParameters(algorithms []string, commit string) {
 	var wrongParameter string
 	switch {
 	case result.Iterations != config.Iterations:
 		wrongParameter = "Iterations"
-	case result.Algorithm != algorithm:
-		wrongParameter = "Algorithm"
+	case !utils.EqualSlices(result.Algorithms, algorithms):
+		wrongParameter = "Algorithms"
 	case !utils.EqualSlices(result.StddevValues, config.StddevValues):
 		wrongParameter = "StddevValues"
 	case result.PointsPerPlane != config.PointsPerPlane:
 		wrongParameter = "PointsPerPlane"
+	case result.CostShape != config.CostShape:
+		wrongParameter = "CostShape"
+	case result.Residual != config.Residual:
+		wrongParameter = "Residual"
+	case result.CostScale != config.CostScale:
+		wrongParameter = "CostScale"
+	case result.Refine != config.Refine:
+		wrongParameter = "Refine"
+	case result.OutlierDistance != config.OutlierDistance:
+		wrongParameter = "OutlierDistance"
+	case result.MinClusterSize != config.MinClusterSize:
+		wrongParameter = "MinClusterSize"
+	case result.PrecomputeCosts != config.PrecomputeCosts:
+		wrongParameter = "PrecomputeCosts"
+	case !utils.EqualSlices(result.Planes, config.Planes):
+		wrongParameter = "Planes"
+	case result.NumberOfPlanes != config.NumberOfPlanes:
+		wrongParameter = "NumberOfPlanes"
+	case result.NoiseMean != config.NoiseMean:
+		wrongParameter = "NoiseMean"
+	case result.DegreesOfFreedom != config.DegreesOfFreedom:
+		wrongParameter = "DegreesOfFreedom"
+	case result.TangentialNoise != config.TangentialNoise:
+		wrongParameter = "TangentialNoise"
+	case result.PatchRadius != config.PatchRadius:
+		wrongParameter = "PatchRadius"
+	case result.OutlierFraction != config.OutlierFraction:
+		wrongParameter = "OutlierFraction"
+	case !equalCostParameters(result.Threshold, config.Threshold):
+		wrongParameter = "Threshold"
+	case !equalCostParameters(result.Amplification, config.Amplification):
+		wrongParameter = "Amplification"
 	}
 
 	if wrongParameter != "" {
@@ -86,17 +272,31 @@ func main() {
 		gitCommit = string(out[:len(out)-1])
 	}
 
-	selectedAlgorithm := flag.String("algorithm", "", "The algorithm which should be used for the partitioning")
+	selectedAlgorithm := flag.String("algorithm", "", "The algorithm which should be used for the partitioning, if empty the algorithms of the config are used")
 	output := flag.String("output", "", "Where the output of the evaluation should be written to, the output will be in json")
 	verbose := flag.Int("verbose", 0, "0: nothing will be printed, 1: Progress bars will indicate the progress of the evaluation")
 	choice := flag.String("choice", "", "What to do if the file already exists, if specified the user will not be requested to give input")
+	workers := flag.Int("workers", 1, "How many iterations are evaluated concurrently, concurrent iterations share the CPU which affects the measured times")
 	configFile := flag.String("config", "./temp/eval_configs/default_config.json", "Which configuration file in src/temp/eval_configs will be used for the evaluation parameters")
 	flag.Parse()
 
 	seed := time.Now().Unix()
-	rand.Seed(seed)
 
 	loadParameters(*configFile)
+	if *workers < 1 {
+		panic("There must be at least one worker")
+	}
+	algorithmNames := config.Algorithms
+	if *selectedAlgorithm != "" {
+		algorithmNames = []string{*selectedAlgorithm}
+	}
+	if len(algorithmNames) == 0 {
+		panic("At least one algorithm must be specified via the command-line argument or the config")
+	}
+	algorithms := make([]algorithm.PhasedAlgorithm[geometry.Vector], len(algorithmNames))
+	for i, name := range algorithmNames {
+		algorithms[i] = algorithm.AlgorithmStringToPhasedFunc[geometry.Vector](name)
+	}
 
 	if _, err := os.Stat(*output); *choice == "" && !errors.Is(err, os.ErrNotExist) {
 		fmt.Printf("The output file seems to exist already, do you want to overwrite it or continue your work or abort [o/c/a]?: ")
@@ -116,89 +316,181 @@ func main() {
 		file, _ := os.Open(*output)
 		content, _ := ioutil.ReadAll(file)
 		json.Unmarshal(content, &result)
-		result.checkParameters(*selectedAlgorithm, gitCommit)
+		result.checkParameters(algorithmNames, gitCommit)
 
 		file.Close()
 	} else if *choice != "o" {
 		os.Exit(0)
 	}
 
-	file, err := os.Create(*output)
-	if err != nil {
-		panic("Could not open the specified output file")
-	}
-	defer file.Close()
-
 	if *choice == "c" {
-		result.outputFile = file
+		result.outputPath = *output
+		result.Workers = *workers
 		result.write()
-		rand.Seed(result.Seed)
 	} else {
 		result = Result{
-			GitCommit:       gitCommit,
-			Algorithm:       *selectedAlgorithm,
-			Iterations:      config.Iterations,
-			StddevValues:    config.StddevValues,
-			PointsPerPlane:  config.PointsPerPlane,
-			Seed:            seed,
-			AccuracyResults: make([]AccuracyResult, 0, len(config.StddevValues)),
-			outputFile:      file,
+			GitCommit:        gitCommit,
+			Algorithms:       algorithmNames,
+			Iterations:       config.Iterations,
+			StddevValues:     config.StddevValues,
+			PointsPerPlane:   config.PointsPerPlane,
+			CostShape:        config.CostShape,
+			Residual:         config.Residual,
+			CostScale:        config.CostScale,
+			Refine:           config.Refine,
+			OutlierDistance:  config.OutlierDistance,
+			MinClusterSize:   config.MinClusterSize,
+			PrecomputeCosts:  config.PrecomputeCosts,
+			Planes:           config.Planes,
+			NumberOfPlanes:   config.NumberOfPlanes,
+			NoiseMean:        config.NoiseMean,
+			DegreesOfFreedom: config.DegreesOfFreedom,
+			TangentialNoise:  config.TangentialNoise,
+			PatchRadius:      config.PatchRadius,
+			OutlierFraction:  config.OutlierFraction,
+			Threshold:        config.Threshold,
+			Amplification:    config.Amplification,
+			Seed:             seed,
+			Workers:          *workers,
+			AccuracyResults:  make([]AccuracyResult, 0, len(config.StddevValues)),
+			outputPath:       *output,
 		}
 		result.write()
 	}
 
-	algorithm := algorithm.AlgorithmStringToFunc[geometry.Vector](*selectedAlgorithm)
-	planes := []geometry.Vector{{X: 1, Y: 0, Z: 0}, {X: 0, Y: 1, Z: 0}, {X: 0, Y: 0, Z: 1}}
 	defer printErrors(result)
 
 	// Determine the starting point if execution of evaluation is continued
 	stddevOffset := len(result.AccuracyResults)
-	if stddevOffset > 0 && len(result.AccuracyResults[stddevOffset-1].Accuracies) < config.Iterations {
+	if stddevOffset > 0 && result.completedIterations(stddevOffset-1) < config.Iterations {
 		stddevOffset--
 	}
-
 	var iterationOffset int
-	if len(result.AccuracyResults)-1 == stddevOffset {
-		iterationOffset = len(result.AccuracyResults[stddevOffset].Accuracies)
-	} else {
-		result.AccuracyResults = append(result.AccuracyResults, AccuracyResult{Accuracies: make([]float64, 0, config.Iterations), Time: 0})
+	if stddevOffset < len(result.AccuracyResults) {
+		iterationOffset = result.completedIterations(stddevOffset)
 	}
 
-	fixSeed(stddevOffset, iterationOffset, planes)
-
 	mainPb := createPb(int64(len(config.StddevValues)), "Stddev values:", *verbose, stddevOffset)
+	var secondaryPb *mpb.Bar
+	if stddevOffset < len(config.StddevValues) {
+		secondaryPb = createPb(int64(config.Iterations), "iterations:", *verbose, iterationOffset)
+	}
 
-	for i := stddevOffset; i < len(config.StddevValues); i++ {
-		stddev := result.StddevValues[i]
-
-		var startIteration int
-		if i == stddevOffset {
-			startIteration = iterationOffset
-		} else {
-			result.AccuracyResults = append(result.AccuracyResults, AccuracyResult{Accuracies: make([]float64, 0, config.Iterations), Time: 0})
+	tasks := make(chan iterationTask)
+	go func() {
+		for i := stddevOffset; i < len(config.StddevValues); i++ {
+			for j := 0; j < config.Iterations; j++ {
+				if i > stddevOffset || j >= iterationOffset {
+					tasks <- iterationTask{stddevIndex: i, iteration: j}
+				}
+			}
 		}
+		close(tasks)
+	}()
+
+	iterations := make(chan iterationResult)
+	for i := 0; i < *workers; i++ {
+		go func() {
+			for task := range tasks {
+				iterations <- evaluateIteration(task, algorithms, result.Seed)
+			}
+		}()
+	}
 
-		var secondaryPb = createPb(int64(config.Iterations), "iterations:", *verbose, startIteration)
-
-		for j := startIteration; j < config.Iterations; j++ {
-			start := time.Now()
-			testData := evaluation.GenerateDataFromPlanesWithNoise(planes, config.PointsPerPlane, utils.NormalDist{Mean: 0, Stddev: stddev})
-			calc := partitioning3D.CostCalculator{Threshold: 3 * stddev, Amplification: 1 / stddev}
-			eval := evaluation.EvaluateAlgorithm(algorithm, &calc, &testData)
+	// The iterations can finish in any order, but they're added to the result in the order of the
+	// tasks, so the output file always contains complete iterations and can be continued
+	next := iterationTask{stddevIndex: stddevOffset, iteration: iterationOffset}
+	pending := make(map[iterationTask]iterationResult)
+	for next.stddevIndex < len(config.StddevValues) {
+		iteration := <-iterations
+		if iteration.err != nil {
+			panic(fmt.Sprintf("Iteration %d of the stddev value %v failed: %v", iteration.iteration, config.StddevValues[iteration.stddevIndex], iteration.err))
+		}
+		pending[iteration.iterationTask] = iteration
 
-			result.AccuracyResults[i].Accuracies = append(result.AccuracyResults[i].Accuracies, eval.Accuracy)
-			result.AccuracyResults[i].Time += time.Since(start).Milliseconds()
+		for iteration, ok := pending[next]; ok; iteration, ok = pending[next] {
+			delete(pending, next)
+			result.addIteration(iteration)
 			result.write()
 			if secondaryPb != nil {
 				secondaryPb.Increment()
 			}
+
+			next.iteration++
+			if next.iteration == config.Iterations {
+				next = iterationTask{stddevIndex: next.stddevIndex + 1}
+				if mainPb != nil {
+					mainPb.Increment()
+				}
+				if next.stddevIndex < len(config.StddevValues) {
+					secondaryPb = createPb(int64(config.Iterations), "iterations:", *verbose, 0)
+				}
+			}
 		}
-		if mainPb != nil {
-			mainPb.Increment()
-		}
 	}
 }
 
+// Generates the data of the given iteration and partitions it with every algorithm. If the config
+// enables it, the costs are precomputed once, so all algorithms use the same precomputed costs.
+// If the iteration panics, the error is returned in the result.
+func evaluateIteration(task iterationTask, algorithms []algorithm.PhasedAlgorithm[geometry.Vector], baseSeed int64) (iteration iterationResult) {
+	iteration = iterationResult{iterationTask: task, seed: utils.DeriveSeed(baseSeed, task.stddevIndex, task.iteration)}
+	defer func() {
+		iteration.err = recover()
+	}()
+	stddev := config.StddevValues[task.stddevIndex]
+
+	start := time.Now()
+	testData := generateTestData(stddev, iteration.seed)
+	iteration.generationTime = milliseconds(time.Since(start))
+
+	var calc algorithm.CostCalculator[geometry.Vector] = createCostCalculator(stddev)
+	if config.PrecomputeCosts {
+		start = time.Now()
+		calc = algorithm.PrecomputeCosts[geometry.Vector](&testData.Points, calc)
+		iteration.precomputationTime = milliseconds(time.Since(start))
+	}
+
+	for _, phasedAlgorithm := range algorithms {
+		iteration.runs = append(iteration.runs, runAlgorithm(phasedAlgorithm, calc, &testData, stddev))
+	}
+	return iteration
+}
+
+// Partitions the test data with the given algorithm and evaluates the partitioning. The
+// initialization and the search of the algorithm are timed separately, the refinement is
+// part of the search.
+func runAlgorithm(phasedAlgorithm algorithm.PhasedAlgorithm[geometry.Vector], calc algorithm.CostCalculator[geometry.Vector],
+	testData *evaluation.TestData, stddev float64) algorithmRun {
+	start := time.Now()
+	search := phasedAlgorithm(&testData.Points, calc)
+	initializationTime := milliseconds(time.Since(start))
+
+	start = time.Now()
+	partitioning := refine(&testData.Points, search(), stddev)
+	searchTime := milliseconds(time.Since(start))
+
+	eval := evaluation.EvaluatePartitioning(partitioning, testData)
+	return algorithmRun{
+		accuracy:           eval.Accuracy,
+		initializationTime: initializationTime,
+		time:               searchTime,
+		objective:          algorithm.Objective(&testData.Points, calc, partitioning),
+		metrics: Metrics{
+			NumOfPlanesError:  eval.NumOfPlanesError,
+			AdjustedRandIndex: eval.Metrics.AdjustedRandIndex,
+			VI:                eval.Metrics.VI,
+			NMI:               eval.Metrics.NMI,
+			MeanAngle:         eval.PlaneRecovery.MeanAngle,
+		},
+	}
+}
+
+// Returns the duration in ms with a resolution of µs
+func milliseconds(duration time.Duration) float64 {
+	return float64(duration.Microseconds()) / 1000
+}
+
 func printErrors(result Result) {
 	err := recover()
 	if err != nil {
@@ -225,12 +517,57 @@ func createPb(total int64, name string, verbose, startProgress int) *mpb.Bar {
 	return mainPb
 }
 
-// This function generates random data so often that the seed which was
-// used in a prior execution now matches with this execution
-func fixSeed(startStddevValue, iterationStart int, planes []geometry.Vector) {
-	for i := 0; i < startStddevValue*config.Iterations+iterationStart; i++ {
-		evaluation.GenerateDataFromPlanesWithNoise(planes, config.PointsPerPlane, utils.NormalDist{Mean: 0, Stddev: 0})
+// Generates the test data of one iteration for the given stddev according to the config, the
+// data is drawn from a random source with the given seed
+func generateTestData(stddev float64, seed int64) evaluation.TestData {
+	options := evaluation.GeneratorOptions{
+		Noise: geometry.NoiseModel{
+			Normal:           utils.NormalDist{Mean: config.NoiseMean * stddev, Stddev: stddev},
+			DegreesOfFreedom: config.DegreesOfFreedom,
+			Tangential:       config.TangentialNoise * stddev,
+		},
+		OutlierFraction: config.OutlierFraction,
+		PatchRadius:     config.PatchRadius,
+		Random:          rand.New(rand.NewSource(seed)),
+	}
+	if config.NumberOfPlanes > 0 {
+		return evaluation.GenerateDataWithOptions(evaluation.EqualPlaneSizes(config.NumberOfPlanes, config.PointsPerPlane), options)
+	}
+	return evaluation.GenerateDataFromPlanesWithOptions(config.Planes, evaluation.EqualPlaneSizes(len(config.Planes), config.PointsPerPlane), options)
+}
+
+// Creates the cost calculator for the given stddev. If the config doesn't specify the threshold
+// it's the residual of three points with a distance of 3 stddev to the plane and if it doesn't
+// specify the amplification it's 1/stddev.
+func createCostCalculator(stddev float64) partitioning3D.CostCalculator {
+	residual := partitioning3D.Residual(config.Residual)
+	threshold := residual.Compute(3*stddev, 3*stddev, 3*stddev)
+	if config.Threshold != nil {
+		threshold = config.Threshold.value(stddev)
+	}
+	amplification := 1 / stddev
+	if config.Amplification != nil {
+		amplification = config.Amplification.value(stddev)
+	}
+	return partitioning3D.CostCalculator{
+		Threshold:     threshold,
+		Amplification: amplification,
+		Shape:         partitioning3D.CostShape(config.CostShape),
+		Residual:      residual,
+		Scale:         config.CostScale * stddev,
+	}
+}
+
+// Refines the partitioning of the points with the refinement of the config for the given stddev,
+// if the config enables it
+func refine(points *[]geometry.Vector, partitioning algorithm.PartitioningArray, stddev float64) algorithm.PartitioningArray {
+	if !config.Refine {
+		return partitioning
 	}
+	return partitioning3D.Refine(points, partitioning, partitioning3D.RefinementParameters{
+		OutlierDistance: config.OutlierDistance * stddev,
+		MinClusterSize:  config.MinClusterSize,
+	})
 }
 
 // This function loads the config in the given file
@@ -252,4 +589,28 @@ func loadParameters(filePath string) {
 	if err != nil {
 		panic("Config file is invalid")
 	}
+
+	if len(config.Planes) > 0 && config.NumberOfPlanes > 0 {
+		panic("Config file is invalid: Planes and NumberOfPlanes can't be specified together")
+	}
+	for _, plane := range config.Planes {
+		if plane.GetLength() == 0 {
+			panic("Config file is invalid: The normals of the planes must not be the zero vector")
+		}
+	}
+	if config.Threshold != nil && config.Threshold.Factor <= 0 {
+		panic("Config file is invalid: The factor of the threshold must be positive")
+	}
+	if config.Amplification != nil && config.Amplification.Factor <= 0 {
+		panic("Config file is invalid: The factor of the amplification must be positive")
+	}
+	for _, stddev := range config.StddevValues {
+		calc := createCostCalculator(stddev)
+		if err := calc.Shape.ValidateScale(calc.Threshold, calc.Scale); err != nil {
+			panic("Config file is invalid: " + err.Error())
+		}
+	}
+	if len(config.Planes) == 0 && config.NumberOfPlanes == 0 {
+		config.Planes = []geometry.Vector{{X: 1, Y: 0, Z: 0}, {X: 0, Y: 1, Z: 0}, {X: 0, Y: 0, Z: 1}}
+	}
 }
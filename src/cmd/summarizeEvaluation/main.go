@@ -0,0 +1,325 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/geometry"
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/utils"
+)
+
+// A result file of runFixedEvaluation. Older files contain only one algorithm whose results
+// are stored directly in the accuracy results.
+type resultFile struct {
+	Algorithm    string
+	Algorithms   []string
+	StddevValues []float64
+	generatorConfig
+	AccuracyResults []struct {
+		Seeds      []int64
+		Accuracies []float64
+		Times      []float64
+		Algorithms []algorithmResult
+	}
+}
+
+// The parameters of the generator of a result file, the data of an iteration only depends on
+// them, the stddev and the seed
+type generatorConfig struct {
+	PointsPerPlane   int
+	Planes           []geometry.Vector
+	NumberOfPlanes   int
+	NoiseMean        float64
+	DegreesOfFreedom float64
+	TangentialNoise  float64
+	PatchRadius      float64
+	OutlierFraction  float64
+}
+
+type algorithmResult struct {
+	Accuracies []float64
+	Times      []float64
+	Objectives []float64
+	Metrics    []map[string]float64
+}
+
+// The values of one metric of one algorithm for one stddev value
+type series struct {
+	algorithm string
+	file      string
+	name      string // The algorithm and, if several files contain it, the file
+	stddev    float64
+	generator string  // The parameters of the generator, series with other parameters were evaluated on other data
+	seeds     []int64 // The seed of every iteration, nil if the file doesn't contain the seeds
+	values    []float64
+}
+
+// The comparison of two series with a Wilcoxon signed-rank test
+type comparison struct {
+	first, second    *series
+	pairs            int
+	medianDifference float64 // The median of the differences first - second
+	test             utils.WilcoxonResult
+}
+
+func main() {
+	metric := flag.String("metric", "Accuracy", "Which value is summarized: Accuracy, Time, Objective or one of the Metrics of the results, e.g. AdjustedRandIndex")
+	format := flag.String("format", "markdown", "The format of the tables: markdown or csv")
+	table := flag.String("table", "all", "Which tables are written: summary, tests or all")
+	confidence := flag.Float64("confidence", 0.95, "The confidence level of the confidence intervals")
+	alpha := flag.Float64("alpha", 0.05, "The significance level of the paired tests")
+	output := flag.String("output", "", "Where the tables are written to, if empty they're printed")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] resultFile...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *format != "markdown" && *format != "csv" {
+		panic("The format must be markdown or csv")
+	}
+	if *table != "summary" && *table != "tests" && *table != "all" {
+		panic("The table must be summary, tests or all")
+	}
+
+	var allSeries []*series
+	for _, path := range flag.Args() {
+		fileSeries, err := loadSeries(path, *metric)
+		if err != nil {
+			panic(err)
+		}
+		allSeries = append(allSeries, fileSeries...)
+	}
+	nameSeries(allSeries)
+
+	var writer io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			panic(err)
+		}
+		defer file.Close()
+		writer = file
+	}
+
+	var tables [][][]string
+	if *table != "tests" {
+		tables = append(tables, summaryTable(allSeries, *confidence))
+	}
+	if *table != "summary" {
+		tables = append(tables, testTable(compare(allSeries), *alpha))
+	}
+	for i, rows := range tables {
+		if i > 0 {
+			fmt.Fprintln(writer)
+		}
+		if *format == "csv" {
+			csvWriter := csv.NewWriter(writer)
+			csvWriter.WriteAll(rows)
+		} else {
+			writeMarkdown(writer, rows)
+		}
+	}
+}
+
+// Reads the result file at the given path and returns the values of the given metric for
+// every algorithm and stddev value. An error is returned if an algorithm has results but no values of the
+// metric, e.g. for the times of the phases in files which only contain the total time
+func loadSeries(path, metric string) ([]*series, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var result resultFile
+	if err := json.Unmarshal(content, &result); err != nil {
+		return nil, fmt.Errorf("%s is not a result file: %v", path, err)
+	}
+
+	generator, err := json.Marshal(result.generatorConfig)
+	if err != nil {
+		return nil, err
+	}
+	var fileSeries []*series
+	for i, accuracyResult := range result.AccuracyResults {
+		if i >= len(result.StddevValues) {
+			return nil, fmt.Errorf("%s contains more results than stddev values", path)
+		}
+		algorithms := result.Algorithms
+		algorithmResults := accuracyResult.Algorithms
+		if algorithms == nil {
+			algorithms = []string{result.Algorithm}
+			algorithmResults = []algorithmResult{{Accuracies: accuracyResult.Accuracies, Times: accuracyResult.Times}}
+		}
+		for j, algorithmResult := range algorithmResults {
+			values, err := metricValues(algorithmResult, metric)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", path, err)
+			}
+			if len(values) == 0 {
+				if len(algorithmResult.Accuracies) > 0 {
+					return nil, fmt.Errorf("%s contains no values of the metric %s for %s", path, metric, algorithms[j])
+				}
+				continue
+			}
+			var seeds []int64
+			if len(accuracyResult.Seeds) == len(values) {
+				seeds = accuracyResult.Seeds
+			}
+			fileSeries = append(fileSeries, &series{
+				algorithm: algorithms[j],
+				file:      path,
+				stddev:    result.StddevValues[i],
+				generator: string(generator),
+				seeds:     seeds,
+				values:    values,
+			})
+		}
+	}
+	return fileSeries, nil
+}
+
+// Returns the values of the metric with the given name in every iteration
+func metricValues(result algorithmResult, metric string) ([]float64, error) {
+	switch metric {
+	case "Accuracy":
+		return result.Accuracies, nil
+	case "Time":
+		return result.Times, nil
+	case "Objective":
+		return result.Objectives, nil
+	}
+	if len(result.Metrics) == 0 && len(result.Accuracies) > 0 {
+		return nil, fmt.Errorf("the metric %s doesn't exist, the file contains no metrics", metric)
+	}
+	values := make([]float64, len(result.Metrics))
+	for i, metrics := range result.Metrics {
+		value, ok := metrics[metric]
+		if !ok {
+			return nil, fmt.Errorf("the metric %s doesn't exist", metric)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// Names the series after their algorithm, if an algorithm is contained in several files the
+// name of the file is added
+func nameSeries(allSeries []*series) {
+	files := make(map[string]map[string]bool)
+	for _, s := range allSeries {
+		if files[s.algorithm] == nil {
+			files[s.algorithm] = make(map[string]bool)
+		}
+		files[s.algorithm][s.file] = true
+	}
+	for _, s := range allSeries {
+		s.name = s.algorithm
+		if len(files[s.algorithm]) > 1 {
+			s.name = fmt.Sprintf("%s (%s)", s.algorithm, filepath.Base(s.file))
+		}
+	}
+}
+
+// Compares all series of the same stddev value and generator parameters whose iterations were
+// evaluated on the same seeds. Only the iterations with a seed that both series contain are compared.
+func compare(allSeries []*series) []comparison {
+	var comparisons []comparison
+	for i, first := range allSeries {
+		for _, second := range allSeries[i+1:] {
+			if first.stddev != second.stddev || first.generator != second.generator || first.seeds == nil || second.seeds == nil {
+				continue
+			}
+			secondIndex := make(map[int64]int, len(second.seeds))
+			for j, seed := range second.seeds {
+				secondIndex[seed] = j
+			}
+			var x, y, differences []float64
+			for j, seed := range first.seeds {
+				if k, ok := secondIndex[seed]; ok {
+					x = append(x, first.values[j])
+					y = append(y, second.values[k])
+					differences = append(differences, first.values[j]-second.values[k])
+				}
+			}
+			if len(x) == 0 {
+				continue
+			}
+			comparisons = append(comparisons, comparison{
+				first:            first,
+				second:           second,
+				pairs:            len(x),
+				medianDifference: utils.Quantile(differences, 0.5),
+				test:             utils.WilcoxonSignedRank(x, y),
+			})
+		}
+	}
+	sort.SliceStable(comparisons, func(i, j int) bool {
+		return comparisons[i].first.stddev < comparisons[j].first.stddev
+	})
+	return comparisons
+}
+
+// Returns the rows of the table with the summary of every series
+func summaryTable(allSeries []*series, confidence float64) [][]string {
+	sorted := append([]*series{}, allSeries...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].stddev < sorted[j].stddev
+	})
+	level := formatFloat(confidence * 100)
+	rows := [][]string{{"Stddev", "Algorithm", "N", "Mean", "Median", "Q1", "Q3", "IQR", level + "% CI lower", level + "% CI upper"}}
+	for _, s := range sorted {
+		summary := utils.Summarize(s.values, confidence)
+		rows = append(rows, []string{
+			formatFloat(s.stddev), s.name, strconv.Itoa(summary.N), formatFloat(summary.Mean), formatFloat(summary.Median),
+			formatFloat(summary.Q1), formatFloat(summary.Q3), formatFloat(summary.IQR()), formatFloat(summary.CILower), formatFloat(summary.CIUpper),
+		})
+	}
+	return rows
+}
+
+// Returns the rows of the table with the paired tests
+func testTable(comparisons []comparison, alpha float64) [][]string {
+	rows := [][]string{{"Stddev", "Algorithm A", "Algorithm B", "Pairs", "Median A-B", "W+", "W-", "p-value", "Method", "Significant"}}
+	for _, c := range comparisons {
+		method := "normal"
+		if c.test.Exact {
+			method = "exact"
+		}
+		significant := "no"
+		if c.test.PValue < alpha {
+			significant = "yes"
+		}
+		rows = append(rows, []string{
+			formatFloat(c.first.stddev), c.first.name, c.second.name, strconv.Itoa(c.pairs), formatFloat(c.medianDifference),
+			formatFloat(c.test.WPlus), formatFloat(c.test.WMinus), formatFloat(c.test.PValue), method, significant,
+		})
+	}
+	return rows
+}
+
+// Writes the rows as markdown table, the first row is the header
+func writeMarkdown(writer io.Writer, rows [][]string) {
+	for i, row := range rows {
+		fmt.Fprintf(writer, "| %s |\n", strings.Join(row, " | "))
+		if i == 0 {
+			fmt.Fprintf(writer, "|%s\n", strings.Repeat(" --- |", len(row)))
+		}
+	}
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', 5, 64)
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const legacyFile = `{"Algorithm": "GreedyJoining", "StddevValues": [0.01, 0.02], "AccuracyResults": [
+	{"Seeds": [1, 2], "Accuracies": [0.9, 0.8], "Times": [5, 6]},
+	{"Accuracies": [0.7, 0.6], "Times": [7, 8]}
+]}`
+
+// A file with several algorithms from before the times of the phases were stored
+const multiAlgorithmFile = `{"Algorithms": ["GreedyJoining", "GreedyMoving"], "StddevValues": [0.01], "PointsPerPlane": 20,
+	"AccuracyResults": [{"Seeds": [3, 4, 5], "Algorithms": [
+		{"Accuracies": [0.9, 0.8, 0.7], "Time": 12, "Objectives": [-3, -2, -1], "Metrics": [{"AdjustedRandIndex": 0.5}, {"AdjustedRandIndex": 0.4}, {"AdjustedRandIndex": 0.3}]},
+		{"Accuracies": [1, 0.9, 0.8], "Time": 15, "Objectives": [-4, -3, -2], "Metrics": [{"AdjustedRandIndex": 0.6}, {"AdjustedRandIndex": 0.5}, {"AdjustedRandIndex": 0.4}]}
+	]}]}`
+
+func TestLoadSeries(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		assert.Nil(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+	legacy := writeFile("legacy.json", legacyFile)
+	multiAlgorithm := writeFile("multi.json", multiAlgorithmFile)
+	tooManyResults := writeFile("tooMany.json", `{"Algorithm": "GreedyJoining", "StddevValues": [], "AccuracyResults": [{"Accuracies": [1]}]}`)
+	invalid := writeFile("invalid.json", `[1, 2]`)
+
+	testTable := []struct {
+		path, metric string
+		algorithms   []string
+		stddevs      []float64
+		seeds        [][]int64
+		values       [][]float64
+		err          bool
+	}{
+		{legacy, "Accuracy", []string{"GreedyJoining", "GreedyJoining"}, []float64{0.01, 0.02},
+			[][]int64{{1, 2}, nil}, [][]float64{{0.9, 0.8}, {0.7, 0.6}}, false},
+		{legacy, "Time", []string{"GreedyJoining", "GreedyJoining"}, []float64{0.01, 0.02},
+			[][]int64{{1, 2}, nil}, [][]float64{{5, 6}, {7, 8}}, false},
+		{legacy, "Objective", nil, nil, nil, nil, true},
+		{legacy, "AdjustedRandIndex", nil, nil, nil, nil, true},
+		{multiAlgorithm, "Accuracy", []string{"GreedyJoining", "GreedyMoving"}, []float64{0.01, 0.01},
+			[][]int64{{3, 4, 5}, {3, 4, 5}}, [][]float64{{0.9, 0.8, 0.7}, {1, 0.9, 0.8}}, false},
+		{multiAlgorithm, "AdjustedRandIndex", []string{"GreedyJoining", "GreedyMoving"}, []float64{0.01, 0.01},
+			[][]int64{{3, 4, 5}, {3, 4, 5}}, [][]float64{{0.5, 0.4, 0.3}, {0.6, 0.5, 0.4}}, false},
+		{multiAlgorithm, "Time", nil, nil, nil, nil, true},
+		{multiAlgorithm, "Unknown", nil, nil, nil, nil, true},
+		{tooManyResults, "Accuracy", nil, nil, nil, nil, true},
+		{invalid, "Accuracy", nil, nil, nil, nil, true},
+		{filepath.Join(dir, "missing.json"), "Accuracy", nil, nil, nil, nil, true},
+	}
+
+	for _, v := range testTable {
+		fileSeries, err := loadSeries(v.path, v.metric)
+		if v.err {
+			assert.NotNil(t, err, "%s with metric %s", filepath.Base(v.path), v.metric)
+			continue
+		}
+		assert.Nil(t, err, "%s with metric %s", filepath.Base(v.path), v.metric)
+		assert.Equal(t, len(v.algorithms), len(fileSeries))
+		for i, s := range fileSeries {
+			assert.Equal(t, v.algorithms[i], s.algorithm)
+			assert.Equal(t, v.path, s.file)
+			assert.Equal(t, v.stddevs[i], s.stddev)
+			assert.Equal(t, v.seeds[i], s.seeds)
+			assert.Equal(t, v.values[i], s.values)
+		}
+	}
+
+	_, err := loadSeries(multiAlgorithm, "Time")
+	assert.ErrorContains(t, err, multiAlgorithm, "The error should name the file")
+	assert.ErrorContains(t, err, "Time", "The error should name the metric")
+}
+
+func TestMetricValues(t *testing.T) {
+	result := algorithmResult{
+		Accuracies: []float64{0.9, 0.8},
+		Times:      []float64{1.5, 2.5},
+		Objectives: []float64{-2, -1},
+		Metrics:    []map[string]float64{{"AdjustedRandIndex": 0.5, "Purity": 1}, {"AdjustedRandIndex": 0.3, "Purity": 0.9}},
+	}
+	testTable := []struct {
+		result   algorithmResult
+		metric   string
+		expected []float64
+		err      bool
+	}{
+		{result, "Accuracy", []float64{0.9, 0.8}, false},
+		{result, "Time", []float64{1.5, 2.5}, false},
+		{result, "Objective", []float64{-2, -1}, false},
+		{result, "AdjustedRandIndex", []float64{0.5, 0.3}, false},
+		{result, "Purity", []float64{1, 0.9}, false},
+		{result, "Unknown", nil, true},
+		{algorithmResult{Accuracies: []float64{0.9}}, "AdjustedRandIndex", nil, true},
+		{algorithmResult{Accuracies: []float64{0.9}}, "Time", nil, false},
+		{algorithmResult{}, "AdjustedRandIndex", []float64{}, false},
+	}
+
+	for _, v := range testTable {
+		values, err := metricValues(v.result, v.metric)
+		if v.err {
+			assert.NotNil(t, err, v.metric)
+		} else {
+			assert.Nil(t, err, v.metric)
+			assert.Equal(t, v.expected, values, v.metric)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	first := &series{algorithm: "A", stddev: 0.01, seeds: []int64{1, 2, 3, 4}, values: []float64{1, 2, 3, 4}}
+	// the seeds are in another order and only 2 and 4 are contained in both series
+	shuffled := &series{algorithm: "B", stddev: 0.01, seeds: []int64{4, 5, 2}, values: []float64{10, 20, 30}}
+	otherStddev := &series{algorithm: "C", stddev: 0.02, seeds: []int64{1, 2, 3, 4}, values: []float64{1, 2, 3, 4}}
+	otherGenerator := &series{algorithm: "D", stddev: 0.01, generator: "other", seeds: []int64{1, 2, 3, 4}, values: []float64{1, 2, 3, 4}}
+	withoutSeeds := &series{algorithm: "E", stddev: 0.01, values: []float64{1, 2, 3, 4}}
+	otherSeeds := &series{algorithm: "F", stddev: 0.01, seeds: []int64{6, 7}, values: []float64{1, 2}}
+
+	testTable := []struct {
+		allSeries        []*series
+		pairs            []int
+		medianDifference []float64
+	}{
+		{[]*series{first, shuffled}, []int{2}, []float64{-17}},
+		{[]*series{first, otherStddev}, []int{}, []float64{}},
+		{[]*series{first, otherGenerator}, []int{}, []float64{}},
+		{[]*series{first, withoutSeeds}, []int{}, []float64{}},
+		{[]*series{first, otherSeeds}, []int{}, []float64{}},
+		{[]*series{first, shuffled, otherStddev, otherGenerator, withoutSeeds}, []int{2}, []float64{-17}},
+	}
+
+	for i, v := range testTable {
+		comparisons := compare(v.allSeries)
+		pairs, medianDifference := []int{}, []float64{}
+		for _, c := range comparisons {
+			pairs = append(pairs, c.pairs)
+			medianDifference = append(medianDifference, c.medianDifference)
+		}
+		assert.Equal(t, v.pairs, pairs, "Case %d", i)
+		assert.Equal(t, v.medianDifference, medianDifference, "Case %d", i)
+	}
+
+	comparisons := compare([]*series{otherStddev, first, shuffled})
+	assert.Equal(t, first, comparisons[0].first, "The comparisons should be sorted by the stddev")
+	assert.Equal(t, 3.0, comparisons[0].test.WMinus, "The values with seed 2 and 4 should be paired")
+}
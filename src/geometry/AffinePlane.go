@@ -0,0 +1,55 @@
+package geometry
+
+import (
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/utils"
+	"math/rand"
+)
+
+// A plane that doesn't necessarily go through the origin. It contains all points x for which
+// the dot product of the normal vector and x equals the offset. If the normal vector has length
+// 1, the offset is the distance of the plane to the origin.
+type AffinePlane struct {
+	Normal Vector
+	Offset float64
+}
+
+// Calculate the distance from the given affine plane to the given point
+func DistFromAffinePlane(plane *AffinePlane, point *Vector) float64 {
+	dot := plane.Normal.X*point.X + plane.Normal.Y*point.Y + plane.Normal.Z*point.Z
+	length := plane.Normal.GetLength()
+	if d := (dot - plane.Offset) / length; d < 0 {
+		return -d
+	} else {
+		return d
+	}
+}
+
+// Create a random affine plane with a normal vector of length 1 and an offset between
+// -maxOffset and maxOffset
+func CreateRandomAffinePlane(maxOffset float64) AffinePlane {
+	return CreateRandomAffinePlaneWithSource(utils.GlobalRandom, maxOffset)
+}
+
+// Same as CreateRandomAffinePlane but the plane is drawn from the given random source
+func CreateRandomAffinePlaneWithSource(random *rand.Rand, maxOffset float64) AffinePlane {
+	return AffinePlane{Normal: CreateRandomUnitVecWithSource(random), Offset: utils.RandomFloatWithSource(random, -maxOffset, maxOffset)}
+}
+
+// Generate a point near the given affine plane with a distance to the plane which
+// is sampled from a normal distribution
+func SamplePointFromAffinePlaneWithNoise(plane AffinePlane, noise utils.NormalDist) Vector {
+	return SamplePointFromAffinePlaneWithNoiseWithSource(utils.GlobalRandom, plane, noise)
+}
+
+// Same as SamplePointFromAffinePlaneWithNoise but the point is drawn from the given random source
+func SamplePointFromAffinePlaneWithNoiseWithSource(random *rand.Rand, plane AffinePlane, noise utils.NormalDist) Vector {
+	point := SamplePointFromPlaneWithNoiseWithSource(random, plane.Normal, noise)
+
+	// move the point from the parallel plane through the origin onto the affine plane
+	shift := plane.Normal
+	length := shift.GetLength()
+	shift.ScalarMultiplication(plane.Offset / (length * length))
+	point.AddVector(shift)
+
+	return point
+}
@@ -0,0 +1,27 @@
+package geometry
+
+import (
+	"testing"
+
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistFromAffinePlane(t *testing.T) {
+	plane := AffinePlane{Normal: Vector{X: 0, Y: 0, Z: 2}, Offset: 4}
+
+	assert.InDelta(t, 0, DistFromAffinePlane(&plane, &Vector{X: 5, Y: -3, Z: 2}), 0.00000001)
+	assert.InDelta(t, 3, DistFromAffinePlane(&plane, &Vector{X: 1, Y: 1, Z: 5}), 0.00000001)
+	assert.InDelta(t, 3, DistFromAffinePlane(&plane, &Vector{X: 1, Y: 1, Z: -1}), 0.00000001)
+}
+
+func TestSamplePointFromAffinePlaneWithNoise(t *testing.T) {
+	for i := 0; i < 5; i++ {
+		plane := CreateRandomAffinePlane(10)
+		assert.LessOrEqual(t, plane.Offset, 10.0)
+		assert.GreaterOrEqual(t, plane.Offset, -10.0)
+
+		point := SamplePointFromAffinePlaneWithNoise(plane, utils.NormalDist{Mean: 0, Stddev: 0})
+		assert.InDelta(t, 0, DistFromAffinePlane(&plane, &point), 0.00000001, "Without noise the point should be on the plane")
+	}
+}
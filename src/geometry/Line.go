@@ -0,0 +1,82 @@
+package geometry
+
+import (
+	m "math"
+	"math/rand"
+
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/utils"
+)
+
+// Calculate the distance from the given line through the origin to the given point
+func DistFromLine(direction, point *Vector) float64 {
+	cross := Vector{
+		X: direction.Y*point.Z - direction.Z*point.Y,
+		Y: direction.Z*point.X - direction.X*point.Z,
+		Z: direction.X*point.Y - direction.Y*point.X,
+	}
+	return cross.GetLength() / direction.GetLength()
+}
+
+// Create a random vector of length 1 in the xy-plane, i.e. its z-coordinate is 0
+func CreateRandomUnitVec2D() Vector {
+	return CreateRandomUnitVec2DWithSource(utils.GlobalRandom)
+}
+
+// Same as CreateRandomUnitVec2D but the angle is drawn from the given random source
+func CreateRandomUnitVec2DWithSource(random *rand.Rand) Vector {
+	angle := utils.RandomFloatWithSource(random, 0, 2*m.Pi)
+	return Vector{X: m.Cos(angle), Y: m.Sin(angle), Z: 0}
+}
+
+// Returns a random vector of length 1 that is orthogonal to the given direction. If the
+// direction is in the xy-plane, the returned vector is in the xy-plane as well.
+func randomOrthogonalUnitVec(random *rand.Rand, direction Vector) Vector {
+	if direction.Z == 0 {
+		orthogonal := Vector{X: -direction.Y, Y: direction.X, Z: 0}
+		orthogonal.ScalarMultiplication(1 / orthogonal.GetLength())
+		if utils.RandomIntWithSource(random, 0, 2) == 0 {
+			orthogonal.ScalarMultiplication(-1)
+		}
+		return orthogonal
+	}
+	for {
+		// project a random vector onto the plane orthogonal to the direction
+		v := CreateRandomVecWithSource(random)
+		scale := (v.X*direction.X + v.Y*direction.Y + v.Z*direction.Z) / (direction.GetLength() * direction.GetLength())
+		v.AddVector(Vector{X: -scale * direction.X, Y: -scale * direction.Y, Z: -scale * direction.Z})
+		if length := v.GetLength(); length > 0.00000001 {
+			v.ScalarMultiplication(1 / length)
+			return v
+		}
+	}
+}
+
+// Given the direction of a line through the origin this function samples one point
+// that is on the line with a distance of at most 1 to the origin
+func SamplePointFromLine(direction Vector) Vector {
+	return SamplePointFromLineWithSource(utils.GlobalRandom, direction)
+}
+
+// Same as SamplePointFromLine but the point is drawn from the given random source
+func SamplePointFromLineWithSource(random *rand.Rand, direction Vector) Vector {
+	direction.ScalarMultiplication(utils.RandomFloatWithSource(random, -1, 1) / direction.GetLength())
+	return direction
+}
+
+// Generate a point near the given line through the origin with a distance to the line which
+// is sampled from a normal distribution. If the direction of the line is in the xy-plane, the
+// point is in the xy-plane as well.
+func SamplePointFromLineWithNoise(direction Vector, noise utils.NormalDist) Vector {
+	return SamplePointFromLineWithNoiseWithSource(utils.GlobalRandom, direction, noise)
+}
+
+// Same as SamplePointFromLineWithNoise but the point is drawn from the given random source
+func SamplePointFromLineWithNoiseWithSource(random *rand.Rand, direction Vector, noise utils.NormalDist) Vector {
+	point := SamplePointFromLineWithSource(random, direction)
+
+	offset := randomOrthogonalUnitVec(random, direction)
+	offset.ScalarMultiplication(utils.FloatFromNormalDistWithSource(random, noise))
+	point.AddVector(offset)
+
+	return point
+}
@@ -0,0 +1,32 @@
+package geometry
+
+import (
+	"testing"
+
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistFromLine(t *testing.T) {
+	direction := Vector{X: 0, Y: 0, Z: 3}
+
+	assert.InDelta(t, 0, DistFromLine(&direction, &Vector{X: 0, Y: 0, Z: -7}), 0.00000001)
+	assert.InDelta(t, 5, DistFromLine(&direction, &Vector{X: 3, Y: 4, Z: 2}), 0.00000001)
+}
+
+func TestSamplePointFromLineWithNoise(t *testing.T) {
+	for i := 0; i < 5; i++ {
+		direction := CreateRandomUnitVec()
+		point := SamplePointFromLineWithNoise(direction, utils.NormalDist{Mean: 0, Stddev: 0})
+		assert.InDelta(t, 0, DistFromLine(&direction, &point), 0.00000001, "Without noise the point should be on the line")
+
+		point = SamplePointFromLineWithNoise(direction, utils.NormalDist{Mean: 2, Stddev: 0})
+		assert.InDelta(t, 2, DistFromLine(&direction, &point), 0.00000001, "The point should have the sampled distance")
+
+		direction = CreateRandomUnitVec2D()
+		assert.InDelta(t, 1, direction.GetLength(), 0.00000001)
+		point = SamplePointFromLineWithNoise(direction, utils.NormalDist{Mean: 2, Stddev: 0})
+		assert.Equal(t, 0.0, point.Z, "Points of lines in the xy-plane should be in the xy-plane")
+		assert.InDelta(t, 2, DistFromLine(&direction, &point), 0.00000001, "The point should have the sampled distance")
+	}
+}
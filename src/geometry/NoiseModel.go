@@ -0,0 +1,146 @@
+package geometry
+
+import (
+	m "math"
+	"math/rand"
+
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/utils"
+)
+
+// Describes the noise that is added to points which are sampled from a plane.
+// The offset along the normal vector of the plane is sampled out of a normal distribution or,
+// if DegreesOfFreedom is positive, out of a heavy-tailed Student-t distribution with the
+// same mean and scale. If Tangential is positive, the point is moved within the plane as
+// well by an offset whose coordinates are sampled out of a normal distribution with this
+// standard deviation, so the noise is anisotropic.
+type NoiseModel struct {
+	Normal           utils.NormalDist
+	DegreesOfFreedom float64
+	Tangential       float64
+}
+
+// An axis-aligned box, Min contains the smallest and Max the largest coordinates
+type BoundingBox struct {
+	Min, Max Vector
+}
+
+// The cube [-1,1]³ in which the points of the test data are usually sampled
+var UnitCube = BoundingBox{Min: Vector{X: -1, Y: -1, Z: -1}, Max: Vector{X: 1, Y: 1, Z: 1}}
+
+// A bounded part of a plane through the origin: the disc with the given radius around the
+// center which lies on the plane
+type PlanePatch struct {
+	Normal Vector
+	Center Vector
+	Radius float64
+}
+
+// Returns the offset along the normal vector of a plane which is sampled from the noise model
+func (noise NoiseModel) SampleOffset() float64 {
+	return noise.SampleOffsetWithSource(utils.GlobalRandom)
+}
+
+// Same as SampleOffset but the offset is drawn from the given random source
+func (noise NoiseModel) SampleOffsetWithSource(random *rand.Rand) float64 {
+	if noise.DegreesOfFreedom > 0 {
+		return utils.FloatFromStudentTWithSource(random, noise.Normal, noise.DegreesOfFreedom)
+	}
+	return utils.FloatFromNormalDistWithSource(random, noise.Normal)
+}
+
+// Moves the given point which is on the plane with the given normal vector according to the noise model
+func (noise NoiseModel) Apply(planeNormalVector Vector, point Vector) Vector {
+	return noise.ApplyWithSource(utils.GlobalRandom, planeNormalVector, point)
+}
+
+// Same as Apply but the noise is drawn from the given random source
+func (noise NoiseModel) ApplyWithSource(random *rand.Rand, planeNormalVector Vector, point Vector) Vector {
+	if noise.Tangential > 0 {
+		tangential := utils.NormalDist{Mean: 0, Stddev: noise.Tangential}
+		u, v := planeBasis(random, planeNormalVector)
+		u.ScalarMultiplication(utils.FloatFromNormalDistWithSource(random, tangential))
+		v.ScalarMultiplication(utils.FloatFromNormalDistWithSource(random, tangential))
+		point.AddVector(u)
+		point.AddVector(v)
+	}
+
+	planeNormalVector.ScalarMultiplication(noise.SampleOffsetWithSource(random) / planeNormalVector.GetLength())
+	point.AddVector(planeNormalVector)
+	return point
+}
+
+// Generate a point near the given plane where the noise is sampled from the given noise model
+func SamplePointFromPlaneWithNoiseModel(planeNormalVector Vector, noise NoiseModel) Vector {
+	return SamplePointFromPlaneWithNoiseModelWithSource(utils.GlobalRandom, planeNormalVector, noise)
+}
+
+// Same as SamplePointFromPlaneWithNoiseModel but the point is drawn from the given random source
+func SamplePointFromPlaneWithNoiseModelWithSource(random *rand.Rand, planeNormalVector Vector, noise NoiseModel) Vector {
+	return noise.ApplyWithSource(random, planeNormalVector, SamplePointFromPlaneWithSource(random, planeNormalVector))
+}
+
+// Returns a point which is sampled uniformly from the bounding box
+func SamplePointFromBox(box BoundingBox) Vector {
+	return SamplePointFromBoxWithSource(utils.GlobalRandom, box)
+}
+
+// Same as SamplePointFromBox but the point is drawn from the given random source
+func SamplePointFromBoxWithSource(random *rand.Rand, box BoundingBox) Vector {
+	return Vector{
+		X: utils.RandomFloatWithSource(random, box.Min.X, box.Max.X),
+		Y: utils.RandomFloatWithSource(random, box.Min.Y, box.Max.Y),
+		Z: utils.RandomFloatWithSource(random, box.Min.Z, box.Max.Z),
+	}
+}
+
+// Creates a patch with the given radius on the plane with the given normal vector whose
+// center is a random point of the plane in the cube [-1,1]³
+func CreateRandomPlanePatch(planeNormalVector Vector, radius float64) PlanePatch {
+	return CreateRandomPlanePatchWithSource(utils.GlobalRandom, planeNormalVector, radius)
+}
+
+// Same as CreateRandomPlanePatch but the center is drawn from the given random source
+func CreateRandomPlanePatchWithSource(random *rand.Rand, planeNormalVector Vector, radius float64) PlanePatch {
+	return PlanePatch{Normal: planeNormalVector, Center: SamplePointFromPlaneWithSource(random, planeNormalVector), Radius: radius}
+}
+
+// Returns a point which is sampled uniformly from the given patch
+func SamplePointFromPatch(patch PlanePatch) Vector {
+	return SamplePointFromPatchWithSource(utils.GlobalRandom, patch)
+}
+
+// Same as SamplePointFromPatch but the point is drawn from the given random source
+func SamplePointFromPatchWithSource(random *rand.Rand, patch PlanePatch) Vector {
+	u, v := planeBasis(random, patch.Normal)
+	radius := patch.Radius * m.Sqrt(utils.RandomFloatWithSource(random, 0, 1))
+	angle := utils.RandomFloatWithSource(random, 0, 2*m.Pi)
+	u.ScalarMultiplication(radius * m.Cos(angle))
+	v.ScalarMultiplication(radius * m.Sin(angle))
+
+	point := patch.Center
+	point.AddVector(u)
+	point.AddVector(v)
+	return point
+}
+
+// Generate a point near the given patch where the noise is sampled from the given noise model
+func SamplePointFromPatchWithNoiseModel(patch PlanePatch, noise NoiseModel) Vector {
+	return SamplePointFromPatchWithNoiseModelWithSource(utils.GlobalRandom, patch, noise)
+}
+
+// Same as SamplePointFromPatchWithNoiseModel but the point is drawn from the given random source
+func SamplePointFromPatchWithNoiseModelWithSource(random *rand.Rand, patch PlanePatch, noise NoiseModel) Vector {
+	return noise.ApplyWithSource(random, patch.Normal, SamplePointFromPatchWithSource(random, patch))
+}
+
+// Returns two orthogonal vectors of length 1 which span the plane with the given normal vector
+func planeBasis(random *rand.Rand, planeNormalVector Vector) (Vector, Vector) {
+	u := randomOrthogonalUnitVec(random, planeNormalVector)
+	v := Vector{
+		X: planeNormalVector.Y*u.Z - planeNormalVector.Z*u.Y,
+		Y: planeNormalVector.Z*u.X - planeNormalVector.X*u.Z,
+		Z: planeNormalVector.X*u.Y - planeNormalVector.Y*u.X,
+	}
+	v.ScalarMultiplication(1 / v.GetLength())
+	return u, v
+}
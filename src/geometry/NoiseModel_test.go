@@ -0,0 +1,45 @@
+package geometry
+
+import (
+	"testing"
+
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSamplePointFromPlaneWithNoiseModel(t *testing.T) {
+	for i := 0; i < 5; i++ {
+		plane := CreateRandomUnitVec()
+
+		point := SamplePointFromPlaneWithNoiseModel(plane, NoiseModel{Normal: utils.NormalDist{Mean: 2, Stddev: 0}, Tangential: 0.5})
+		assert.InDelta(t, 2, DistFromPlane(&plane, &point), 0.00000001, "Tangential noise shouldn't change the distance to the plane")
+
+		point = SamplePointFromPlaneWithNoiseModel(plane, NoiseModel{Normal: utils.NormalDist{Mean: 2, Stddev: 0}, DegreesOfFreedom: 3})
+		assert.InDelta(t, 2, DistFromPlane(&plane, &point), 0.00000001, "The point should have the sampled distance")
+	}
+}
+
+func TestSamplePointFromBox(t *testing.T) {
+	box := BoundingBox{Min: Vector{X: 1, Y: -2, Z: 0}, Max: Vector{X: 2, Y: -1, Z: 5}}
+	for i := 0; i < 20; i++ {
+		point := SamplePointFromBox(box)
+		assert.True(t, point.X >= 1 && point.X < 2 && point.Y >= -2 && point.Y < -1 && point.Z >= 0 && point.Z < 5,
+			"The point should be in the bounding box")
+	}
+}
+
+func TestSamplePointFromPatch(t *testing.T) {
+	for i := 0; i < 5; i++ {
+		patch := CreateRandomPlanePatch(CreateRandomUnitVec(), 0.3)
+		assert.InDelta(t, 0, DistFromPlane(&patch.Normal, &patch.Center), 0.00000001, "The center should be on the plane")
+
+		for j := 0; j < 10; j++ {
+			point := SamplePointFromPatch(patch)
+			assert.InDelta(t, 0, DistFromPlane(&patch.Normal, &point), 0.00000001, "The point should be on the plane")
+			assert.LessOrEqual(t, VectorDist(point, patch.Center), 0.3+0.00000001, "The point should be in the patch")
+
+			point = SamplePointFromPatchWithNoiseModel(patch, NoiseModel{Normal: utils.NormalDist{Mean: 0.1, Stddev: 0}})
+			assert.InDelta(t, 0.1, DistFromPlane(&patch.Normal, &point), 0.00000001)
+		}
+	}
+}
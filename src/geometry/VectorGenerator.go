@@ -2,25 +2,36 @@ package geometry
 
 import (
 	m "math"
+	"math/rand"
 
 	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/utils"
 )
 
 // Create a random vector where each coordinate has a value between -1 and 1
 func CreateRandomVec() Vector {
-	x := utils.RandomFloat(-1, 1)
-	y := utils.RandomFloat(-1, 1)
-	z := utils.RandomFloat(-1, 1)
+	return CreateRandomVecWithSource(utils.GlobalRandom)
+}
+
+// Same as CreateRandomVec but the coordinates are drawn from the given random source
+func CreateRandomVecWithSource(random *rand.Rand) Vector {
+	x := utils.RandomFloatWithSource(random, -1, 1)
+	y := utils.RandomFloatWithSource(random, -1, 1)
+	z := utils.RandomFloatWithSource(random, -1, 1)
 
 	return Vector{x, y, z}
 }
 
 // Same as CreateRandomVec but the vector has length 1
 func CreateRandomUnitVec() Vector {
-	x := utils.RandomFloat(-1, 1)
-	y := utils.RandomFloat(-m.Sqrt(1-m.Pow(x, 2)), m.Sqrt(1-m.Pow(x, 2)))
+	return CreateRandomUnitVecWithSource(utils.GlobalRandom)
+}
+
+// Same as CreateRandomUnitVec but the coordinates are drawn from the given random source
+func CreateRandomUnitVecWithSource(random *rand.Rand) Vector {
+	x := utils.RandomFloatWithSource(random, -1, 1)
+	y := utils.RandomFloatWithSource(random, -m.Sqrt(1-m.Pow(x, 2)), m.Sqrt(1-m.Pow(x, 2)))
 	z := m.Sqrt(1 - m.Pow(x, 2) - m.Pow(y, 2)) // will always be positive
-	if utils.RandomInt(0, 2) == 0 {
+	if utils.RandomIntWithSource(random, 0, 2) == 0 {
 		z *= -1 // negate z with 50% probability
 	}
 
@@ -30,7 +41,12 @@ func CreateRandomUnitVec() Vector {
 // Given a plane normal vector this function samples one point
 // that is on the plane
 func SamplePointFromPlane(planeNormalVector Vector) Vector {
-	point := CreateRandomVec()
+	return SamplePointFromPlaneWithSource(utils.GlobalRandom, planeNormalVector)
+}
+
+// Same as SamplePointFromPlane but the point is drawn from the given random source
+func SamplePointFromPlaneWithSource(random *rand.Rand, planeNormalVector Vector) Vector {
+	point := CreateRandomVecWithSource(random)
 	dist := (planeNormalVector.X*point.X + planeNormalVector.Y*point.Y + planeNormalVector.Z*point.Z) / planeNormalVector.GetLength()
 	planeNormalVector.ScalarMultiplication(-dist / planeNormalVector.GetLength())
 
@@ -45,10 +61,15 @@ func SamplePointFromPlane(planeNormalVector Vector) Vector {
 // Generate a point near the given plane with a distance to the plane which
 // is sampled from a normal distribution
 func SamplePointFromPlaneWithNoise(planeNormalVector Vector, noise utils.NormalDist) Vector {
-	distance := utils.FloatFromNormalDist(noise)
+	return SamplePointFromPlaneWithNoiseWithSource(utils.GlobalRandom, planeNormalVector, noise)
+}
+
+// Same as SamplePointFromPlaneWithNoise but the point is drawn from the given random source
+func SamplePointFromPlaneWithNoiseWithSource(random *rand.Rand, planeNormalVector Vector, noise utils.NormalDist) Vector {
+	distance := utils.FloatFromNormalDistWithSource(random, noise)
 
 	// add this vector to a sampled point from the plane
-	point := SamplePointFromPlane(planeNormalVector)
+	point := SamplePointFromPlaneWithSource(random, planeNormalVector)
 
 	// scale the planeNormalVector s.t. it has the length of the distance we want to move
 	planeNormalVector.ScalarMultiplication(distance / planeNormalVector.GetLength())
@@ -1,6 +1,7 @@
 package geometry
 
 import (
+	"math/rand"
 	"testing"
 
 	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/utils"
@@ -55,3 +56,21 @@ func TestSamplePointFromPlaneWithNoise(t *testing.T) {
 	}
 	assert.True(t, outside2Stddev, "This test should fail with probability of 0.0035%")
 }
+
+func TestGeneratorsWithSource(t *testing.T) {
+	noise := utils.NormalDist{Mean: 0, Stddev: 0.1}
+	generate := func(seed int64) []Vector {
+		random := rand.New(rand.NewSource(seed))
+		plane := CreateRandomUnitVecWithSource(random)
+		return []Vector{
+			plane,
+			CreateRandomVecWithSource(random),
+			SamplePointFromPlaneWithNoiseWithSource(random, plane, noise),
+			SamplePointFromLineWithNoiseWithSource(random, plane, noise),
+			SamplePointFromAffinePlaneWithNoiseWithSource(random, CreateRandomAffinePlaneWithSource(random, 1), noise),
+			SamplePointFromPatchWithNoiseModelWithSource(random, CreateRandomPlanePatchWithSource(random, plane, 0.5), NoiseModel{Normal: noise, Tangential: 0.1}),
+		}
+	}
+	assert.Equal(t, generate(5), generate(5), "The same seed should give the same vectors")
+	assert.NotEqual(t, generate(5), generate(6))
+}
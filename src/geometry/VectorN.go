@@ -0,0 +1,139 @@
+package geometry
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/utils"
+)
+
+// A Vector in a real-valued vector space of arbitrary dimension
+type VectorN []float64
+
+// Returns the number of coordinates of the vector
+func (v VectorN) Dimension() int {
+	return len(v)
+}
+
+// Get the length of the vector
+func (v VectorN) GetLength() float64 {
+	return math.Sqrt(v.Dot(v))
+}
+
+// Computes the dot product of the two vectors. This function panics if
+// the vectors have different dimensions.
+func (v VectorN) Dot(other VectorN) float64 {
+	if len(v) != len(other) {
+		panic("The vectors must have the same dimension")
+	}
+	sum := 0.0
+	for i := range v {
+		sum += v[i] * other[i]
+	}
+	return sum
+}
+
+// Returns the projection of the given point onto the linear subspace which is spanned
+// by the given orthonormal basis
+func ProjectOntoSubspace(basis []VectorN, point VectorN) VectorN {
+	projection := make(VectorN, len(point))
+	for _, b := range basis {
+		factor := b.Dot(point)
+		for i := range projection {
+			projection[i] += factor * b[i]
+		}
+	}
+	return projection
+}
+
+// Calculate the distance from the linear subspace which is spanned by the given
+// orthonormal basis to the given point
+func DistFromSubspace(basis []VectorN, point *VectorN) float64 {
+	projection := ProjectOntoSubspace(basis, *point)
+	for i := range projection {
+		projection[i] = (*point)[i] - projection[i]
+	}
+	return projection.GetLength()
+}
+
+// Create a random vector with the given dimension where each coordinate has a value between -1 and 1
+func CreateRandomVecN(dimension int) VectorN {
+	return CreateRandomVecNWithSource(utils.GlobalRandom, dimension)
+}
+
+// Same as CreateRandomVecN but the coordinates are drawn from the given random source
+func CreateRandomVecNWithSource(random *rand.Rand, dimension int) VectorN {
+	v := make(VectorN, dimension)
+	for i := range v {
+		v[i] = utils.RandomFloatWithSource(random, -1, 1)
+	}
+	return v
+}
+
+// Create an orthonormal basis of a random linear subspace with the given dimension in a
+// vector space with the given ambient dimension
+func CreateRandomSubspace(ambientDimension, dimension int) []VectorN {
+	return CreateRandomSubspaceWithSource(utils.GlobalRandom, ambientDimension, dimension)
+}
+
+// Same as CreateRandomSubspace but the basis is drawn from the given random source
+func CreateRandomSubspaceWithSource(random *rand.Rand, ambientDimension, dimension int) []VectorN {
+	if dimension < 1 || dimension > ambientDimension {
+		panic("The dimension of the subspace must be between 1 and the ambient dimension")
+	}
+	basis := make([]VectorN, 0, dimension)
+	for len(basis) < dimension {
+		// Gram-Schmidt orthonormalization of a random vector
+		v := CreateRandomVecNWithSource(random, ambientDimension)
+		projection := ProjectOntoSubspace(basis, v)
+		for i := range v {
+			v[i] -= projection[i]
+		}
+		length := v.GetLength()
+		if length < 0.00000001 {
+			continue
+		}
+		for i := range v {
+			v[i] /= length
+		}
+		basis = append(basis, v)
+	}
+	return basis
+}
+
+// Generate a point near the linear subspace which is spanned by the given orthonormal basis.
+// The coefficients of the point in the subspace are between -1 and 1 and the distance to the
+// subspace is sampled from a normal distribution.
+func SamplePointFromSubspaceWithNoise(basis []VectorN, noise utils.NormalDist) VectorN {
+	return SamplePointFromSubspaceWithNoiseWithSource(utils.GlobalRandom, basis, noise)
+}
+
+// Same as SamplePointFromSubspaceWithNoise but the point is drawn from the given random source
+func SamplePointFromSubspaceWithNoiseWithSource(random *rand.Rand, basis []VectorN, noise utils.NormalDist) VectorN {
+	ambientDimension := len(basis[0])
+	point := make(VectorN, ambientDimension)
+	for _, b := range basis {
+		factor := utils.RandomFloatWithSource(random, -1, 1)
+		for i := range point {
+			point[i] += factor * b[i]
+		}
+	}
+	if len(basis) == ambientDimension {
+		return point
+	}
+
+	// a random direction which is orthogonal to the subspace
+	var direction VectorN
+	for length := 0.0; length < 0.00000001; length = direction.GetLength() {
+		direction = CreateRandomVecNWithSource(random, ambientDimension)
+		projection := ProjectOntoSubspace(basis, direction)
+		for i := range direction {
+			direction[i] -= projection[i]
+		}
+	}
+	scale := utils.FloatFromNormalDistWithSource(random, noise) / direction.GetLength()
+	for i := range point {
+		point[i] += scale * direction[i]
+	}
+	return point
+}
@@ -0,0 +1,45 @@
+package geometry
+
+import (
+	"testing"
+
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVectorN(t *testing.T) {
+	v := VectorN{3, 0, 4, 0}
+
+	assert.Equal(t, 4, v.Dimension())
+	assert.InDelta(t, 5, v.GetLength(), 0.00000001)
+	assert.InDelta(t, 11, v.Dot(VectorN{1, 7, 2, 9}), 0.00000001)
+	assert.Panics(t, func() { v.Dot(VectorN{1, 2}) })
+}
+
+func TestDistFromSubspace(t *testing.T) {
+	basis := []VectorN{{1, 0, 0, 0}, {0, 1, 0, 0}}
+
+	assert.InDelta(t, 0, DistFromSubspace(basis, &VectorN{5, -2, 0, 0}), 0.00000001)
+	assert.InDelta(t, 5, DistFromSubspace(basis, &VectorN{5, -2, 3, 4}), 0.00000001)
+}
+
+func TestCreateRandomSubspace(t *testing.T) {
+	basis := CreateRandomSubspace(5, 3)
+
+	assert.Equal(t, 3, len(basis))
+	for i := range basis {
+		for j := range basis {
+			expected := 0.0
+			if i == j {
+				expected = 1
+			}
+			assert.InDelta(t, expected, basis[i].Dot(basis[j]), 0.00000001, "The basis should be orthonormal")
+		}
+	}
+	assert.Panics(t, func() { CreateRandomSubspace(2, 3) })
+
+	point := SamplePointFromSubspaceWithNoise(basis, utils.NormalDist{Mean: 0, Stddev: 0})
+	assert.InDelta(t, 0, DistFromSubspace(basis, &point), 0.00000001, "Without noise the point should be in the subspace")
+	point = SamplePointFromSubspaceWithNoise(basis, utils.NormalDist{Mean: 2, Stddev: 0})
+	assert.InDelta(t, 2, DistFromSubspace(basis, &point), 0.00000001, "The point should have the sampled distance")
+}
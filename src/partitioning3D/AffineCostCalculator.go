@@ -0,0 +1,178 @@
+package partitioning3D
+
+import (
+	"errors"
+
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/geometry"
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/utils"
+)
+
+// How the affine cost calculator combines a triple with the nearest neighbors of its points.
+// The zero value is the quadruple mode.
+type AffineMode string
+
+const (
+	QuadrupleMode   AffineMode = "quadruples"  // the median of the quadruple costs of the triple with every neighbor
+	NeighborFitMode AffineMode = "neighborFit" // one plane is fitted through the triple and all neighbors
+)
+
+// Converts the given string into an affine mode, an empty string is the quadruple mode.
+// If the string isn't an affine mode an error is returned.
+func ParseAffineMode(mode string) (AffineMode, error) {
+	switch AffineMode(mode) {
+	case "", QuadrupleMode:
+		return QuadrupleMode, nil
+	case NeighborFitMode:
+		return NeighborFitMode, nil
+	}
+	return "", errors.New("The affine mode must be one of quadruples or neighborFit")
+}
+
+// A struct for calculating costs of points that are sampled from affine planes, i.e. planes
+// that don't have to go through the origin. Four points determine an affine plane, so the
+// basic cost is the QuadrupleCost. Three points always lie exactly on a common affine plane
+// (for planes through the origin the origin is the fourth point), so the algorithms, which
+// only use triple costs, need a fourth point. Therefore the triple cost takes the nearest
+// neighbors of the three points in the input into account, how depends on the mode:
+//   - QuadrupleMode: the triple cost is the median of the quadruple costs of the triple with
+//     each of the neighbors. At an intersection of planes some neighbors are from the other
+//     plane, the median ignores them as long as most neighbors are from the plane of the triple.
+//   - NeighborFitMode: an affine plane is fitted through the triple and all neighbors and the
+//     costs depend on the maximum distance of one of these points to the plane.
+type AffineCostCalculator struct {
+	Threshold     float64    // The distance of a point to a plane where costs a zero
+	Amplification float64    // A factor that is used in the cost calculation
+	Mode          AffineMode // How the triple is combined with the neighbors
+	input         *[]geometry.Vector
+	indices       map[*geometry.Vector]int
+	neighbors     [][]int // The indices of the nearest neighbors of every point in the input
+}
+
+// Creates a cost calculator for affine planes which uses the given number of nearest neighbors
+// of every point in the given input. The returned calculator must only be used with this input,
+// for all other points only the triple itself is used which always yields a distance of 0.
+func CreateAffineCostCalculator(input *[]geometry.Vector, threshold, amplification float64, neighbors int) *AffineCostCalculator {
+	if neighbors < 0 {
+		panic("The number of neighbors must not be negative")
+	}
+	n := len(*input)
+	if neighbors > n-1 {
+		neighbors = n - 1
+	}
+
+	calc := AffineCostCalculator{
+		Threshold:     threshold,
+		Amplification: amplification,
+		input:         input,
+		indices:       make(map[*geometry.Vector]int, n),
+		neighbors:     make([][]int, n),
+	}
+	for i := range *input {
+		calc.indices[&(*input)[i]] = i
+	}
+	if neighbors == 0 {
+		return &calc
+	}
+	for i := range *input {
+		calc.neighbors[i] = nearestNeighbors(input, i, neighbors)
+	}
+	return &calc
+}
+
+// Returns the indices of the k points which are closest to the point at the given index, sorted
+// by their distance. Of points with the same distance the one with the smaller index comes first.
+// The k nearest points are kept sorted while iterating over the input, so it takes O(n * k) time.
+func nearestNeighbors(input *[]geometry.Vector, index, k int) []int {
+	nearest := make([]int, 0, k)
+	distances := make([]float64, 0, k)
+	for j, point := range *input {
+		if j == index {
+			continue
+		}
+		distance := geometry.VectorDist((*input)[index], point)
+		if len(nearest) == k && distance >= distances[k-1] {
+			continue
+		}
+		position := len(nearest)
+		for position > 0 && distances[position-1] > distance {
+			position--
+		}
+		if len(nearest) < k {
+			nearest = append(nearest, 0)
+			distances = append(distances, 0)
+		}
+		copy(nearest[position+1:], nearest[position:])
+		copy(distances[position+1:], distances[position:])
+		nearest[position] = j
+		distances[position] = distance
+	}
+	return nearest
+}
+
+// This function computes the cost for three points that are in the same partition from the
+// triple and the nearest neighbors of its points according to the mode. Neighbors which are
+// in the triple or which are a neighbor of several points of the triple are only used once.
+// If no point has a neighbor, the distance of the triple to its plane is used, which is 0.
+func (calc *AffineCostCalculator) TripleCost(v1, v2, v3 *geometry.Vector) float64 {
+	triple := []*geometry.Vector{v1, v2, v3}
+	var neighbors []*geometry.Vector
+	for _, v := range triple {
+		i, ok := calc.indices[v]
+		if !ok {
+			continue
+		}
+		for _, neighbor := range calc.neighbors[i] {
+			if point := &(*calc.input)[neighbor]; !containsPoint(triple, point) && !containsPoint(neighbors, point) {
+				neighbors = append(neighbors, point)
+			}
+		}
+	}
+	if len(neighbors) == 0 {
+		return -calc.Amplification * calc.Threshold
+	}
+
+	switch calc.Mode {
+	case "", QuadrupleMode:
+		residuals := make([]float64, len(neighbors))
+		for i, neighbor := range neighbors {
+			plane := FitAffinePlane(v1, v2, v3, neighbor)
+			residuals[i] = GetMaxAffineDist(&plane, v1, v2, v3, neighbor)
+		}
+		return calc.Amplification * (utils.Quantile(residuals, 0.5) - calc.Threshold)
+	case NeighborFitMode:
+		points := append(triple, neighbors...)
+		plane := FitAffinePlane(points...)
+		return calc.Amplification * (GetMaxAffineDist(&plane, points...) - calc.Threshold)
+	}
+	panic("Unknown affine mode " + string(calc.Mode))
+}
+
+// This function computes the cost for four points that are in the same partition. It fits
+// an affine plane through the points and calculates costs depending on the maximum distance
+// of one point to the plane
+func (calc *AffineCostCalculator) QuadrupleCost(v1, v2, v3, v4 *geometry.Vector) float64 {
+	plane := FitAffinePlane(v1, v2, v3, v4)
+	return calc.Amplification * (GetMaxAffineDist(&plane, v1, v2, v3, v4) - calc.Threshold)
+}
+
+// Returns whether the given point is one of the points
+func containsPoint(points []*geometry.Vector, point *geometry.Vector) bool {
+	for _, p := range points {
+		if p == point {
+			return true
+		}
+	}
+	return false
+}
+
+// This function accepts an affine plane and an arbitrary number of vectors
+// and computes the maximal distance from a point to the plane
+func GetMaxAffineDist(plane *geometry.AffinePlane, vectors ...*geometry.Vector) float64 {
+	maxDist := 0.0
+	for _, vector := range vectors {
+		if d := geometry.DistFromAffinePlane(plane, vector); d > maxDist {
+			maxDist = d
+		}
+	}
+	return maxDist
+}
@@ -0,0 +1,85 @@
+package partitioning3D
+
+import (
+	"testing"
+
+	g "github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/geometry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFitAffinePlane(t *testing.T) {
+	plane := FitAffinePlane(&g.Vector{X: 1, Y: 0, Z: 5}, &g.Vector{X: 0, Y: 1, Z: 5}, &g.Vector{X: -3, Y: 2, Z: 5}, &g.Vector{X: 7, Y: 7, Z: 5})
+	assert.InDelta(t, 1, plane.Normal.GetLength(), delta)
+	assert.InDelta(t, 1, plane.Normal.Z*plane.Normal.Z, delta, "The plane is parallel to the xy-plane")
+	assert.InDelta(t, 5, plane.Normal.Z*plane.Offset, delta, "The plane has a distance of 5 to the origin")
+
+	p1 := g.Vector{X: 1, Y: 0, Z: 0}
+	p2 := g.Vector{X: 0, Y: 1, Z: 0}
+	p3 := g.Vector{X: 0, Y: 0, Z: 1}
+	plane = FitAffinePlane(&p1, &p2, &p3)
+	assert.InDelta(t, 0, GetMaxAffineDist(&plane, &p1, &p2, &p3), delta, "Three points are always on an affine plane")
+}
+
+func TestAffineTripleCost(t *testing.T) {
+	input := []g.Vector{
+		{X: 0, Y: 0, Z: 3}, {X: 1, Y: 0, Z: 3}, {X: 0, Y: 1, Z: 3}, {X: 1, Y: 1, Z: 3},
+		{X: 10, Y: 0, Z: 0}, {X: 10, Y: 1, Z: 0}, {X: 10, Y: 0, Z: 1}, {X: 10, Y: 1, Z: 1},
+	}
+	for _, mode := range []AffineMode{"", QuadrupleMode, NeighborFitMode} {
+		calc := CreateAffineCostCalculator(&input, 0.1, 2, 3)
+		calc.Mode = mode
+
+		assert.InDelta(t, -0.2, calc.TripleCost(&input[0], &input[1], &input[2]), delta, "All neighbors are on the same plane")
+		assert.Less(t, 0.0, calc.TripleCost(&input[0], &input[1], &input[4]), "The neighbors aren't on the fitted plane")
+
+		other := g.Vector{X: 5, Y: 5, Z: 5}
+		assert.InDelta(t, -0.2, calc.TripleCost(&other, &g.Vector{}, &g.Vector{X: 1}), delta, "Points which aren't in the input have no neighbors")
+	}
+
+	// the fifth point is on the plane z=3 but its nearest neighbor is on the plane x=2
+	input = []g.Vector{
+		{X: 0, Y: 0, Z: 3}, {X: 0, Y: 1, Z: 3}, {X: 0, Y: 2, Z: 3}, {X: 1, Y: 0, Z: 3}, {X: 1.9, Y: 0, Z: 3},
+		{X: 2, Y: 0, Z: 3.05}, {X: 2, Y: 0, Z: 4}, {X: 2, Y: 1, Z: 4},
+	}
+	calc := CreateAffineCostCalculator(&input, 0.1, 2, 2)
+	assert.InDelta(t, -0.2, calc.TripleCost(&input[0], &input[2], &input[4]), delta,
+		"The median ignores the neighbor from the other plane")
+	calc.Mode = NeighborFitMode
+	assert.Less(t, -0.2, calc.TripleCost(&input[0], &input[2], &input[4]), "The neighbor from the other plane isn't on the fitted plane")
+
+	calc.Mode = "median"
+	assert.Panics(t, func() { calc.TripleCost(&input[0], &input[1], &input[2]) })
+	assert.Panics(t, func() { CreateAffineCostCalculator(&input, 0.5, 2, -1) })
+}
+
+func TestQuadrupleCost(t *testing.T) {
+	calc := AffineCostCalculator{Threshold: 2, Amplification: 4}
+
+	assert.InDelta(t, -8,
+		calc.QuadrupleCost(&g.Vector{X: 0, Y: 0, Z: 3}, &g.Vector{X: 1, Y: 0, Z: 3}, &g.Vector{X: 0, Y: 1, Z: 3}, &g.Vector{X: 5, Y: 9, Z: 3}),
+		delta,
+		"The points are all in the fitted plane",
+	)
+	assert.Less(t, -8.0,
+		calc.QuadrupleCost(&g.Vector{X: 0, Y: 0, Z: 3}, &g.Vector{X: 1, Y: 0, Z: 3}, &g.Vector{X: 0, Y: 1, Z: 3}, &g.Vector{X: 5, Y: 9, Z: 4}),
+		"The points aren't in one plane",
+	)
+}
+
+func TestParseAffineMode(t *testing.T) {
+	mode, err := ParseAffineMode("")
+	assert.Nil(t, err)
+	assert.Equal(t, QuadrupleMode, mode)
+	mode, err = ParseAffineMode("neighborFit")
+	assert.Nil(t, err)
+	assert.Equal(t, NeighborFitMode, mode)
+	_, err = ParseAffineMode("centroid")
+	assert.NotNil(t, err)
+}
+
+func TestNearestNeighbors(t *testing.T) {
+	input := []g.Vector{{X: 0}, {X: 5}, {X: 1}, {X: -1}, {X: 3}, {X: 2}, {X: 1}}
+	assert.Equal(t, []int{2, 3, 6, 5}, nearestNeighbors(&input, 0, 4), "Points with the same distance are sorted by their index")
+	assert.Equal(t, []int{4}, nearestNeighbors(&input, 1, 1))
+	assert.Equal(t, []int{0, 2, 6, 5, 4, 1}, nearestNeighbors(&input, 3, 6))
+}
@@ -0,0 +1,190 @@
+package partitioning3D
+
+import (
+	"math"
+
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/geometry"
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/utils"
+)
+
+// The parameters for calibrating the costs from a noise model
+type CalibrationParameters struct {
+	Noise          utils.NormalDist // The noise of the distances of the points to their plane
+	ExpectedPlanes int              // The prior on the number of planes in the data
+	PriorWeight    float64          // How much the prior log-odds of the same plane are added to the costs, between 0 and 1
+	Samples        int              // How many triples are sampled for each of the two distributions
+	Bins           int              // How many bins the histograms of the maximum distances have
+}
+
+// The parameters that are used for the values which aren't set in the calibration parameters
+var DefaultCalibrationParameters = CalibrationParameters{ExpectedPlanes: 3, Samples: 20000, Bins: 50}
+
+// A cost calculator whose costs are the negative log-likelihood ratio of the hypothesis that the
+// three points are sampled from the same plane against the hypothesis that they aren't, given
+// the maximum distance of the points to the best fitting plane. It's created by Calibrate.
+type CalibratedCostCalculator struct {
+	binWidth float64
+	costs    []float64 // The costs for the maximum distances in every bin
+}
+
+// The result of a calibration. The calculator uses the log-likelihood-ratio costs, the threshold
+// and the amplification approximate these costs with the linear cost calculation.
+type Calibration struct {
+	Calculator    *CalibratedCostCalculator
+	Threshold     float64 // The maximum distance where the costs are 0
+	Amplification float64 // The slope of the costs
+}
+
+// This function computes the cost for three points that are in the same partition. It fits
+// a plane through the points and looks up the costs for the maximum distance of one point to the plane.
+func (calc *CalibratedCostCalculator) TripleCost(v1, v2, v3 *geometry.Vector) float64 {
+	plane := FitPlane(v1, v2, v3)
+	return calc.Cost(GetMaxDist(&plane, v1, v2, v3))
+}
+
+// Returns the costs for the given maximum distance of three points to their plane
+func (calc *CalibratedCostCalculator) Cost(maxDistance float64) float64 {
+	bin := int(maxDistance / calc.binWidth)
+	if bin >= len(calc.costs) {
+		bin = len(calc.costs) - 1
+	}
+	return calc.costs[bin]
+}
+
+// Returns the linear cost calculator with the calibrated threshold and amplification
+func (calibration Calibration) CostCalculator() CostCalculator {
+	return CostCalculator{Threshold: calibration.Threshold, Amplification: calibration.Amplification}
+}
+
+// Derives the costs from the given noise model with a Monte Carlo simulation. Triples are sampled
+// with the generators of the geometry package from the same random plane and from different random
+// planes to estimate the distributions of the maximum distance to the best fitting plane under both
+// hypotheses. The triples from different planes are mixed like in data with the expected number
+// of planes and equally many points per plane, which also determines the prior probability that
+// three points are from the same plane. With a prior weight of 1 the costs are the negative
+// posterior log-odds. The prior is small for every triple, so with a high weight most costs
+// become positive already for moderate noise. Like the generators it assumes coordinates
+// between -1 and 1.
+func Calibrate(parameters CalibrationParameters) Calibration {
+	if parameters.ExpectedPlanes == 0 {
+		parameters.ExpectedPlanes = DefaultCalibrationParameters.ExpectedPlanes
+	}
+	if parameters.Samples == 0 {
+		parameters.Samples = DefaultCalibrationParameters.Samples
+	}
+	if parameters.Bins == 0 {
+		parameters.Bins = DefaultCalibrationParameters.Bins
+	}
+	if parameters.ExpectedPlanes < 2 {
+		panic("At least 2 planes must be expected")
+	} else if parameters.Samples < 1 || parameters.Bins < 1 {
+		panic("The number of samples and bins must be positive")
+	} else if parameters.PriorWeight < 0 || parameters.PriorWeight > 1 {
+		panic("The weight of the prior must be between 0 and 1")
+	}
+
+	k := float64(parameters.ExpectedPlanes)
+	// the probabilities that a random triple of the data has 2 or 3 points of different planes
+	twoPlanes := 3 * (k - 1) / (k * k)
+	threePlanes := (k - 1) * (k - 2) / (k * k)
+	priorSame := 1 / (k * k)
+
+	same := make([]float64, parameters.Samples)
+	different := make([]float64, parameters.Samples)
+	maxDistance := 0.0 // the histograms only resolve the range of the distances of the same plane
+	for i := 0; i < parameters.Samples; i++ {
+		plane := geometry.CreateRandomUnitVec()
+		same[i] = sampleMaxDistance(parameters.Noise, plane, plane, plane)
+
+		if utils.RandomFloat(0, twoPlanes+threePlanes) < twoPlanes {
+			other := geometry.CreateRandomUnitVec()
+			different[i] = sampleMaxDistance(parameters.Noise, plane, plane, other)
+		} else {
+			different[i] = sampleMaxDistance(parameters.Noise, plane, geometry.CreateRandomUnitVec(), geometry.CreateRandomUnitVec())
+		}
+		maxDistance = math.Max(maxDistance, same[i])
+	}
+
+	bins := parameters.Bins
+	binWidth := maxDistance / float64(bins)
+	if binWidth == 0 {
+		binWidth = 1
+	}
+	sameCounts := histogram(same, binWidth, bins)
+	differentCounts := histogram(different, binWidth, bins)
+
+	// the costs are the negative log-likelihood ratio plus the weighted prior log-odds, the
+	// counts are smoothed to avoid empty bins
+	priorLogOdds := math.Log(priorSame / (1 - priorSame))
+	costs := make([]float64, bins)
+	for i := range costs {
+		likelihoodSame := (sameCounts[i] + 1) / float64(parameters.Samples+bins)
+		likelihoodDifferent := (differentCounts[i] + 1) / float64(parameters.Samples+bins)
+		costs[i] = -(math.Log(likelihoodSame/likelihoodDifferent) + parameters.PriorWeight*priorLogOdds)
+	}
+	calc := &CalibratedCostCalculator{binWidth: binWidth, costs: costs}
+
+	return Calibration{
+		Calculator:    calc,
+		Threshold:     zeroCrossing(costs, binWidth),
+		Amplification: slope(costs, sameCounts, differentCounts, binWidth),
+	}
+}
+
+// Samples one point from every given plane and returns the maximum distance of the points to
+// their best fitting plane
+func sampleMaxDistance(noise utils.NormalDist, plane1, plane2, plane3 geometry.Vector) float64 {
+	v1 := geometry.SamplePointFromPlaneWithNoise(plane1, noise)
+	v2 := geometry.SamplePointFromPlaneWithNoise(plane2, noise)
+	v3 := geometry.SamplePointFromPlaneWithNoise(plane3, noise)
+	plane := FitPlane(&v1, &v2, &v3)
+	return GetMaxDist(&plane, &v1, &v2, &v3)
+}
+
+// Counts how many of the values are in each bin, values beyond the last bin are counted in the last bin
+func histogram(values []float64, binWidth float64, bins int) []float64 {
+	counts := make([]float64, bins)
+	for _, value := range values {
+		bin := int(value / binWidth)
+		if bin >= bins {
+			bin = bins - 1
+		}
+		counts[bin]++
+	}
+	return counts
+}
+
+// Returns the first maximum distance at which the costs become positive, the value is
+// interpolated linearly between the centers of the bins
+func zeroCrossing(costs []float64, binWidth float64) float64 {
+	if costs[0] >= 0 {
+		return 0
+	}
+	for i := 1; i < len(costs); i++ {
+		if costs[i] >= 0 {
+			fraction := -costs[i-1] / (costs[i] - costs[i-1])
+			return (float64(i-1) + 0.5 + fraction) * binWidth
+		}
+	}
+	return float64(len(costs)) * binWidth
+}
+
+// Fits a line through the costs of the bins which contain at least one sample and returns its slope
+func slope(costs, sameCounts, differentCounts []float64, binWidth float64) float64 {
+	var n, sumX, sumY, sumXX, sumXY float64
+	for i, cost := range costs {
+		if sameCounts[i]+differentCounts[i] == 0 {
+			continue
+		}
+		x := (float64(i) + 0.5) * binWidth
+		n++
+		sumX += x
+		sumY += cost
+		sumXX += x * x
+		sumXY += x * cost
+	}
+	if denominator := n*sumXX - sumX*sumX; denominator != 0 {
+		return (n*sumXY - sumX*sumY) / denominator
+	}
+	return 0
+}
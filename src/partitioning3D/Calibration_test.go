@@ -0,0 +1,36 @@
+package partitioning3D
+
+import (
+	"math/rand"
+	"testing"
+
+	g "github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/geometry"
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalibrate(t *testing.T) {
+	rand.Seed(1)
+	noise := utils.NormalDist{Mean: 0, Stddev: 0.01}
+	calibration := Calibrate(CalibrationParameters{Noise: noise, ExpectedPlanes: 3, Samples: 5000})
+
+	assert.Less(t, calibration.Calculator.Cost(0.001), 0.0, "Small distances are more likely for points of the same plane")
+	assert.Greater(t, calibration.Calculator.Cost(0.5), 0.0, "Large distances are more likely for points of different planes")
+	assert.Greater(t, calibration.Threshold, 0.1*noise.Stddev)
+	assert.Less(t, calibration.Threshold, 10*noise.Stddev)
+	assert.Greater(t, calibration.Amplification, 0.0)
+
+	calc := calibration.CostCalculator()
+	assert.Equal(t, calibration.Threshold, calc.Threshold)
+	assert.Equal(t, calibration.Amplification, calc.Amplification)
+
+	plane := g.Vector{X: 0, Y: 0, Z: 1}
+	v1, v2, v3 := g.Vector{X: 1, Y: 0, Z: 0}, g.Vector{X: 0, Y: 1, Z: 0}, g.Vector{X: 1, Y: 1, Z: 0}
+	assert.Equal(t, calibration.Calculator.Cost(GetMaxDist(&plane, &v1, &v2, &v3)), calibration.Calculator.TripleCost(&v1, &v2, &v3))
+
+	withPrior := Calibrate(CalibrationParameters{Noise: noise, ExpectedPlanes: 3, Samples: 5000, PriorWeight: 1})
+	assert.Less(t, withPrior.Threshold, calibration.Threshold, "The prior favors different planes")
+
+	assert.Panics(t, func() { Calibrate(CalibrationParameters{Noise: noise, ExpectedPlanes: 1}) })
+	assert.Panics(t, func() { Calibrate(CalibrationParameters{Noise: noise, PriorWeight: 2}) })
+}
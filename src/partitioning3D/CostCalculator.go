@@ -4,20 +4,24 @@ import (
 	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/geometry"
 )
 
-// A struct for calculating costs.
+// A struct for calculating costs. If only the threshold and the amplification are set,
+// the costs are linear in the maximum distance of one point to the plane.
 type CostCalculator struct {
-	Threshold     float64 // The distance of a point to a plane where costs a zero
-	Amplification float64 // A factor that is used in the cost calculation
+	Threshold     float64   // The residual of the points where costs a zero
+	Amplification float64   // A factor that is used in the cost calculation
+	Shape         CostShape // How the residual is transformed into costs
+	Residual      Residual  // How the distances of the points to the plane are combined
+	Scale         float64   // The scale of the logistic, hinge and truncated quadratic shape
 }
 
 // This function computes the cost for three points that are in the same partition
-// It fits a plane through the points and calculates costs depending on the maximum
-// distance of one point to the plane
+// It fits a plane through the points and calculates costs depending on the residual
+// of the distances of the points to the plane
 func (calc CostCalculator) TripleCost(v1, v2, v3 *geometry.Vector) float64 {
 	plane := FitPlane(v1, v2, v3)
-	maxDistance := GetMaxDist(&plane, v1, v2, v3)
+	residual := calc.Residual.Compute(geometry.DistFromPlane(&plane, v1), geometry.DistFromPlane(&plane, v2), geometry.DistFromPlane(&plane, v3))
 
-	return calc.Amplification * (maxDistance - calc.Threshold)
+	return calc.Shape.Apply(residual, calc.Threshold, calc.Amplification, calc.Scale)
 }
 
 // This function accepts a plane normal vector and an arbitrary number of vectors
@@ -0,0 +1,105 @@
+package partitioning3D
+
+import (
+	"errors"
+	"math"
+)
+
+// How the residual of a triple is transformed into costs. The zero value is the linear shape.
+type CostShape string
+
+const (
+	Linear             CostShape = "linear"             // amplification * (residual - threshold)
+	Logistic           CostShape = "logistic"           // a logistic curve between -amplification and amplification which is 0 at the threshold
+	Hinge              CostShape = "hinge"              // linear up to threshold + scale and constant above
+	TruncatedQuadratic CostShape = "truncatedQuadratic" // amplification * (residual² - threshold²) where the residual is truncated at threshold + scale
+)
+
+// How the distances of the three points to the fitted plane are combined into one residual.
+// The zero value is the maximum distance.
+type Residual string
+
+const (
+	MaxDistance  Residual = "max"          // the maximum distance
+	RMSDistance  Residual = "rms"          // the root mean square of the distances
+	SumOfSquares Residual = "sumOfSquares" // the sum of the squared distances
+)
+
+// Converts the given string into a cost shape, an empty string is the linear shape.
+// If the string isn't a cost shape an error is returned.
+func ParseCostShape(shape string) (CostShape, error) {
+	switch CostShape(shape) {
+	case "", Linear:
+		return Linear, nil
+	case Logistic, Hinge, TruncatedQuadratic:
+		return CostShape(shape), nil
+	}
+	return "", errors.New("The cost shape must be one of linear, logistic, hinge or truncatedQuadratic")
+}
+
+// Returns an error if the shape depends on the scale and the scale which is used by Apply isn't
+// positive, then the logistic curve would divide by zero and the hinge and truncated quadratic
+// shape would be constant.
+func (shape CostShape) ValidateScale(threshold, scale float64) error {
+	if scale == 0 {
+		scale = threshold
+	}
+	if shape != "" && shape != Linear && scale <= 0 {
+		return errors.New("The scale of the cost shape must be positive, if it's 0 the threshold must be positive")
+	}
+	return nil
+}
+
+// Converts the given string into a residual, an empty string is the maximum distance.
+// If the string isn't a residual an error is returned.
+func ParseResidual(residual string) (Residual, error) {
+	switch Residual(residual) {
+	case "", MaxDistance:
+		return MaxDistance, nil
+	case RMSDistance, SumOfSquares:
+		return Residual(residual), nil
+	}
+	return "", errors.New("The residual must be one of max, rms or sumOfSquares")
+}
+
+// Combines the given distances into the residual
+func (residual Residual) Compute(distances ...float64) float64 {
+	switch residual {
+	case "", MaxDistance:
+		maxDist := 0.0
+		for _, d := range distances {
+			maxDist = math.Max(maxDist, d)
+		}
+		return maxDist
+	case RMSDistance, SumOfSquares:
+		sum := 0.0
+		for _, d := range distances {
+			sum += d * d
+		}
+		if residual == SumOfSquares {
+			return sum
+		}
+		return math.Sqrt(sum / float64(len(distances)))
+	}
+	panic("Unknown residual " + string(residual))
+}
+
+// Computes the costs for the given residual. The scale controls the width of the logistic curve
+// and where the hinge and truncated quadratic shape are truncated, if it's 0 the threshold is used.
+func (shape CostShape) Apply(residual, threshold, amplification, scale float64) float64 {
+	if scale == 0 {
+		scale = threshold
+	}
+	switch shape {
+	case "", Linear:
+		return amplification * (residual - threshold)
+	case Logistic:
+		return amplification * (2/(1+math.Exp(-(residual-threshold)/scale)) - 1)
+	case Hinge:
+		return amplification * (math.Min(residual, threshold+scale) - threshold)
+	case TruncatedQuadratic:
+		truncated := math.Min(residual, threshold+scale)
+		return amplification * (truncated*truncated - threshold*threshold)
+	}
+	panic("Unknown cost shape " + string(shape))
+}
@@ -0,0 +1,73 @@
+package partitioning3D
+
+import (
+	"math"
+	"testing"
+
+	g "github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/geometry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResidual(t *testing.T) {
+	assert.Equal(t, 4.0, MaxDistance.Compute(1, 4, 2))
+	assert.Equal(t, 4.0, Residual("").Compute(1, 4, 2), "The default residual is the maximum distance")
+	assert.InDelta(t, math.Sqrt(7), RMSDistance.Compute(1, 4, 2), delta)
+	assert.Equal(t, 21.0, SumOfSquares.Compute(1, 4, 2))
+	assert.Panics(t, func() { Residual("mean").Compute(1) })
+}
+
+func TestCostShape(t *testing.T) {
+	assert.Equal(t, 6.0, Linear.Apply(5, 2, 2, 0))
+	assert.Equal(t, 6.0, CostShape("").Apply(5, 2, 2, 0), "The default shape is linear")
+
+	assert.Equal(t, 0.0, Logistic.Apply(2, 2, 3, 1))
+	assert.InDelta(t, 3, Logistic.Apply(100, 2, 3, 1), delta, "The logistic shape is bounded by the amplification")
+	assert.InDelta(t, -3*math.Tanh(1), Logistic.Apply(0, 2, 3, 1), delta)
+
+	assert.Equal(t, -4.0, Hinge.Apply(0, 2, 2, 0))
+	assert.Equal(t, 2.0, Hinge.Apply(3, 2, 2, 0))
+	assert.Equal(t, 4.0, Hinge.Apply(100, 2, 2, 0), "The hinge shape is constant above threshold + scale")
+
+	assert.Equal(t, -8.0, TruncatedQuadratic.Apply(0, 2, 2, 1))
+	assert.Equal(t, 10.0, TruncatedQuadratic.Apply(3, 2, 2, 1))
+	assert.Equal(t, 10.0, TruncatedQuadratic.Apply(100, 2, 2, 1), "The residual is truncated at threshold + scale")
+
+	assert.Panics(t, func() { CostShape("cubic").Apply(1, 1, 1, 1) })
+}
+
+func TestParseCostShapeAndResidual(t *testing.T) {
+	shape, err := ParseCostShape("")
+	assert.Nil(t, err)
+	assert.Equal(t, Linear, shape)
+	shape, err = ParseCostShape("truncatedQuadratic")
+	assert.Nil(t, err)
+	assert.Equal(t, TruncatedQuadratic, shape)
+	_, err = ParseCostShape("cubic")
+	assert.NotNil(t, err)
+
+	assert.Nil(t, Logistic.ValidateScale(0.1, 0))
+	assert.Nil(t, Hinge.ValidateScale(0, 0.1))
+	assert.Nil(t, Linear.ValidateScale(0, 0), "The linear shape doesn't depend on the scale")
+	assert.NotNil(t, Logistic.ValidateScale(0, 0), "The threshold is used as scale if the scale is 0")
+	assert.NotNil(t, TruncatedQuadratic.ValidateScale(-0.1, 0))
+	assert.NotNil(t, Hinge.ValidateScale(0.1, -0.1))
+
+	residual, err := ParseResidual("rms")
+	assert.Nil(t, err)
+	assert.Equal(t, RMSDistance, residual)
+	_, err = ParseResidual("mean")
+	assert.NotNil(t, err)
+}
+
+func TestTripleCostWithShape(t *testing.T) {
+	v1, v2, v3 := g.Vector{X: 1, Y: 0, Z: 0}, g.Vector{X: 0, Y: 1, Z: 0}, g.Vector{X: 0, Y: 0, Z: 1}
+	plane := FitPlane(&v1, &v2, &v3)
+	d1, d2, d3 := g.DistFromPlane(&plane, &v1), g.DistFromPlane(&plane, &v2), g.DistFromPlane(&plane, &v3)
+
+	calc := CostCalculator{Threshold: 0.1, Amplification: 2, Residual: SumOfSquares, Shape: Hinge, Scale: 0.2}
+	expected := 2 * (math.Min(d1*d1+d2*d2+d3*d3, 0.3) - 0.1)
+	assert.InDelta(t, expected, calc.TripleCost(&v1, &v2, &v3), delta)
+
+	calc = CostCalculator{Threshold: 0.1, Amplification: 2}
+	assert.InDelta(t, 2*(GetMaxDist(&plane, &v1, &v2, &v3)-0.1), calc.TripleCost(&v1, &v2, &v3), delta, "By default the costs are linear in the maximum distance")
+}
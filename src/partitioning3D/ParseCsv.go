@@ -14,21 +14,42 @@ import (
 // Parses the 3D points which are stored in the given file and returns the data
 // as a pointer to a geometry.Vector slice. If the parsing fails, an error is returned.
 func ParsePoints(path string) (*[]geometry.Vector, error) {
-	err := verifyPath(path)
+	points, _, err := ParseLabeledPoints(path)
+	return points, err
+}
+
+// Same as ParsePoints but the z-coordinate is optional, points without a z-coordinate get
+// a z-coordinate of 0. This is used for the points of lines which may be in 2D.
+func Parse2DOr3DPoints(path string) (*[]geometry.Vector, error) {
+	points, _, err := parseFile(path, true)
+	return points, err
+}
+
+// Parses the 3D points and their ground truth labels which are stored in the given file.
+// The labels are read from the column with the head 'label', the label -1 means that the
+// point is noise. If the file has no label column, the labels are nil. If the parsing fails,
+// an error is returned.
+func ParseLabeledPoints(path string) (*[]geometry.Vector, []int, error) {
+	return parseFile(path, false)
+}
+
+func parseFile(path string, optionalZ bool) (*[]geometry.Vector, []int, error) {
+	err := VerifyPath(path)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	file, err := os.Open(path)
 	defer file.Close()
 
 	if err != nil {
-		return nil, errors.New("The specified file wasn't found!")
+		return nil, nil, errors.New("The specified file wasn't found!")
 	}
-	return parseCsv(file)
+	return parseCsv(file, optionalZ)
 }
 
-func verifyPath(path string) error {
+// Returns an error if the given path is empty or doesn't refer to a csv file
+func VerifyPath(path string) error {
 	if path == "" {
 		return errors.New("The path to a file with geometry data that should be partitioned must be provided as argument")
 	} else if !strings.HasSuffix(path, ".csv") {
@@ -37,22 +58,29 @@ func verifyPath(path string) error {
 	return nil
 }
 
-func parseCsv(file *os.File) (*[]geometry.Vector, error) {
+// Parses the points of a csv. The head of the csv may specify the columns of the coordinates
+// with 'x', 'y' and 'z'. Otherwise the first three columns are assumed to contain the
+// coordinates. If optionalZ is true, the z-coordinate may be missing, so the head doesn't have
+// to specify it and a csv without head may have only two columns.
+func parseCsv(file io.Reader, optionalZ bool) (*[]geometry.Vector, []int, error) {
 	reader := csv.NewReader(file)
 
 	row, err := reader.Read()
 	if err == io.EOF {
-		return nil, errors.New("The file is empty")
+		return nil, nil, errors.New("The file is empty")
 	} else if err != nil {
-		return nil, err
-	} else if len(row) < 3 {
-		return nil, errors.New("The csv file must contain at least 3 columns for x, y and z coordinates!")
+		return nil, nil, err
+	} else if optionalZ && len(row) < 2 {
+		return nil, nil, errors.New("The csv file must contain at least 2 columns for x and y coordinates!")
+	} else if !optionalZ && len(row) < 3 {
+		return nil, nil, errors.New("The csv file must contain at least 3 columns for x, y and z coordinates!")
 	}
 
 	data := []geometry.Vector{}
+	var labels []int
 
-	// find out column indices of x, y and z coordinate
-	xIdx, yIdx, zIdx := -1, -1, -1
+	// find out column indices of x, y and z coordinate and of the label
+	xIdx, yIdx, zIdx, labelIdx := -1, -1, -1, -1
 	for i, value := range row {
 		switch strings.ToLower(value) {
 		case "x":
@@ -61,6 +89,8 @@ func parseCsv(file *os.File) (*[]geometry.Vector, error) {
 			yIdx = i
 		case "z":
 			zIdx = i
+		case "label":
+			labelIdx = i
 		}
 	}
 	if xIdx+yIdx+zIdx == -3 {
@@ -69,15 +99,23 @@ func parseCsv(file *os.File) (*[]geometry.Vector, error) {
 		// second is the y-coordinate and third row is z-coordinate
 		xIdx = 0
 		yIdx = 1
-		zIdx = 2
-		if vector, err := rowToVector(row[xIdx], row[yIdx], row[zIdx]); err != nil {
-			return nil, err
+		if len(row) >= 3 {
+			zIdx = 2
+		}
+		labelIdx = -1
+		if vector, err := rowToVector(row, xIdx, yIdx, zIdx); err != nil {
+			return nil, nil, err
 		} else {
 			data = append(data, *vector)
 		}
-	} else if xIdx == -1 || yIdx == -1 || zIdx == -1 {
+	} else if xIdx == -1 || yIdx == -1 {
+		return nil, nil, errors.New("Csv head doesn't specify the x and y coordinates!")
+	} else if zIdx == -1 && !optionalZ {
 		// some x, y or z is specified but not all so panic
-		return nil, errors.New("Csv head doesn't specify each of the x, y and z coordinates!")
+		return nil, nil, errors.New("Csv head doesn't specify each of the x, y and z coordinates!")
+	}
+	if labelIdx != -1 {
+		labels = []int{}
 	}
 
 	for {
@@ -86,24 +124,35 @@ func parseCsv(file *os.File) (*[]geometry.Vector, error) {
 		if err == io.EOF {
 			break
 		} else if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
-		if vector, err := rowToVector(row[xIdx], row[yIdx], row[zIdx]); err != nil {
-			return nil, err
+		if vector, err := rowToVector(row, xIdx, yIdx, zIdx); err != nil {
+			return nil, nil, err
 		} else {
 			data = append(data, *vector)
 		}
+		if labelIdx != -1 {
+			label, err := strconv.Atoi(strings.TrimSpace(row[labelIdx]))
+			if err != nil {
+				return nil, nil, errors.New("Couldn't convert a label in the csv into an integer")
+			}
+			labels = append(labels, label)
+		}
 	}
-	return &data, nil
+	return &data, labels, nil
 }
 
-// Try to convert 3 string into a vector by converting each
-// into a float. It this fails the function returns an error.
-func rowToVector(v1, v2, v3 string) (*geometry.Vector, error) {
-	x, errX := strconv.ParseFloat(v1, 64)
-	y, errY := strconv.ParseFloat(v2, 64)
-	z, errZ := strconv.ParseFloat(v3, 64)
+// Try to convert the cells at the given indices into a vector by converting each
+// into a float. If zIdx is -1, the z-coordinate is 0. It this fails the function
+// returns an error.
+func rowToVector(row []string, xIdx, yIdx, zIdx int) (*geometry.Vector, error) {
+	x, errX := strconv.ParseFloat(row[xIdx], 64)
+	y, errY := strconv.ParseFloat(row[yIdx], 64)
+	z, errZ := 0.0, error(nil)
+	if zIdx != -1 {
+		z, errZ = strconv.ParseFloat(row[zIdx], 64)
+	}
 
 	if errX != nil || errY != nil || errZ != nil {
 		return nil, errors.New("Couldn't convert a cell in the csv into a float")
@@ -0,0 +1,58 @@
+package partitioning3D
+
+import (
+	"strings"
+	"testing"
+
+	g "github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/geometry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCsv(t *testing.T) {
+	points, labels, err := parseCsv(strings.NewReader("z,x,y\n3,1,2\n6,4,5\n"), false)
+	assert.Nil(t, err)
+	assert.Equal(t, []g.Vector{{X: 1, Y: 2, Z: 3}, {X: 4, Y: 5, Z: 6}}, *points)
+	assert.Nil(t, labels, "Without a label column there are no labels")
+
+	points, labels, err = parseCsv(strings.NewReader("1,2,3\n4,5,6\n"), false)
+	assert.Nil(t, err)
+	assert.Equal(t, []g.Vector{{X: 1, Y: 2, Z: 3}, {X: 4, Y: 5, Z: 6}}, *points, "The first row is data if there is no head")
+	assert.Nil(t, labels)
+
+	_, _, err = parseCsv(strings.NewReader("x,y\n1,2\n"), false)
+	assert.NotNil(t, err)
+}
+
+func TestParseLabeledCsv(t *testing.T) {
+	points, labels, err := parseCsv(strings.NewReader("x,Label,y,z\n1,0,2,3\n4,-1,5,6\n7, 3,8,9\n"), false)
+	assert.Nil(t, err)
+	assert.Equal(t, []g.Vector{{X: 1, Y: 2, Z: 3}, {X: 4, Y: 5, Z: 6}, {X: 7, Y: 8, Z: 9}}, *points)
+	assert.Equal(t, []int{0, -1, 3}, labels)
+
+	points, labels, err = parseCsv(strings.NewReader("x,y,z,label\n"), false)
+	assert.Nil(t, err)
+	assert.Equal(t, []g.Vector{}, *points)
+	assert.Equal(t, []int{}, labels)
+
+	_, _, err = parseCsv(strings.NewReader("x,y,z,label\n1,2,3,a\n"), false)
+	assert.NotNil(t, err, "Labels must be integers")
+}
+
+func TestParse2DOr3DCsv(t *testing.T) {
+	points, _, err := parseCsv(strings.NewReader("y,x\n1,2\n3,4\n"), true)
+	assert.Nil(t, err)
+	assert.Equal(t, []g.Vector{{X: 2, Y: 1, Z: 0}, {X: 4, Y: 3, Z: 0}}, *points)
+
+	points, _, err = parseCsv(strings.NewReader("1,2\n3,4\n"), true)
+	assert.Nil(t, err)
+	assert.Equal(t, []g.Vector{{X: 1, Y: 2, Z: 0}, {X: 3, Y: 4, Z: 0}}, *points, "The first row is data if there is no head")
+
+	points, _, err = parseCsv(strings.NewReader("1,2,3\n4,5,6\n"), true)
+	assert.Nil(t, err)
+	assert.Equal(t, []g.Vector{{X: 1, Y: 2, Z: 3}, {X: 4, Y: 5, Z: 6}}, *points)
+
+	_, _, err = parseCsv(strings.NewReader("x,z\n1,2\n"), true)
+	assert.NotNil(t, err)
+	_, _, err = parseCsv(strings.NewReader("x,y\n1,a\n"), true)
+	assert.NotNil(t, err)
+}
@@ -46,3 +46,27 @@ func FitPlane(points ...*geometry.Vector) geometry.Vector {
 
 	return geometry.Vector{X: x, Y: y, Z: z}
 }
+
+// This function takes in an arbitrary number of points and finds the best affine plane
+// that minimizes the sum of squared distances from the points to the plane. The plane
+// goes through the centroid of the points and its normal vector has length 1.
+func FitAffinePlane(points ...*geometry.Vector) geometry.AffinePlane {
+	centroid := geometry.Vector{}
+	for _, point := range points {
+		centroid.AddVector(*point)
+	}
+	centroid.ScalarMultiplication(1 / float64(len(points)))
+
+	centered := make([]geometry.Vector, len(points))
+	centeredPointers := make([]*geometry.Vector, len(points))
+	for i, point := range points {
+		centered[i] = geometry.Vector{X: point.X - centroid.X, Y: point.Y - centroid.Y, Z: point.Z - centroid.Z}
+		centeredPointers[i] = &centered[i]
+	}
+	normal := FitPlane(centeredPointers...)
+
+	return geometry.AffinePlane{
+		Normal: normal,
+		Offset: normal.X*centroid.X + normal.Y*centroid.Y + normal.Z*centroid.Z,
+	}
+}
@@ -0,0 +1,149 @@
+package partitioning3D
+
+import (
+	"math"
+	"sort"
+
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/algorithm"
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/geometry"
+)
+
+// The label of points which are too far away from every plane after a refinement
+const OutlierLabel = algorithm.NoiseLabel
+
+// The parameters for refining a partitioning
+type RefinementParameters struct {
+	MaxIterations   int     // How often planes are fitted and points are reassigned at most
+	OutlierDistance float64 // Points with a larger distance to every plane are outliers, if 0 there are no outliers
+	MinClusterSize  int     // Clusters with fewer points are dissolved and their points are reassigned
+}
+
+// The parameters that are used for the values which aren't set in the refinement parameters
+var DefaultRefinementParameters = RefinementParameters{MaxIterations: 10, MinClusterSize: 3}
+
+// Refines the given partitioning of the points by alternating between fitting a plane to every
+// cluster and reassigning every point to the cluster with the nearest plane. Points whose distance
+// to the nearest plane is larger than the outlier distance are labeled as outliers. Clusters
+// which have fewer points than the minimum cluster size are dissolved, their points are assigned
+// to the remaining clusters, also if the maximum number of iterations is reached. The clusters
+// of the result are numbered by the order of their first point and outliers have the label
+// OutlierLabel.
+func Refine(points *[]geometry.Vector, partitioning algorithm.PartitioningArray, parameters RefinementParameters) algorithm.PartitioningArray {
+	if parameters.MaxIterations == 0 {
+		parameters.MaxIterations = DefaultRefinementParameters.MaxIterations
+	}
+	if parameters.MinClusterSize == 0 {
+		parameters.MinClusterSize = DefaultRefinementParameters.MinClusterSize
+	}
+	if len(*points) != len(partitioning) {
+		panic("The partitioning must have as many elements as there are points")
+	}
+
+	result := make(algorithm.PartitioningArray, len(partitioning))
+	copy(result, partitioning)
+
+	for iteration := 0; iteration < parameters.MaxIterations; iteration++ {
+		planes := fitClusterPlanes(points, result, parameters.MinClusterSize)
+		if len(planes) == 0 {
+			break
+		}
+
+		changed := false
+		for i := range *points {
+			label := nearestPlane(planes, &(*points)[i], parameters.OutlierDistance)
+			if label != result[i] {
+				result[i] = label
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+
+	// if the last iteration reached the maximum, some clusters may have become too small, so
+	// they are dissolved one last time without refitting the planes of the other clusters
+	planes := fitClusterPlanes(points, result, parameters.MinClusterSize)
+	for i := range *points {
+		if _, ok := planes[result[i]]; !ok && result[i] != OutlierLabel {
+			result[i] = nearestPlane(planes, &(*points)[i], parameters.OutlierDistance)
+		}
+	}
+	return relabel(result)
+}
+
+// Returns the given algorithm whose partitioning is refined with the given parameters
+func WithRefinement(partitioningAlgorithm algorithm.PartitioningAlgorithm[geometry.Vector], parameters RefinementParameters) algorithm.PartitioningAlgorithm[geometry.Vector] {
+	return func(input *[]geometry.Vector, calc algorithm.CostCalculator[geometry.Vector]) algorithm.PartitioningArray {
+		return Refine(input, partitioningAlgorithm(input, calc), parameters)
+	}
+}
+
+// Fits a plane to every cluster with at least the given number of points and returns the
+// planes by the label of their cluster. If no cluster is large enough, the plane of the
+// largest cluster is returned.
+func fitClusterPlanes(points *[]geometry.Vector, partitioning algorithm.PartitioningArray, minClusterSize int) map[int]geometry.Vector {
+	clusters := make(map[int][]*geometry.Vector)
+	for i, label := range partitioning {
+		if label != OutlierLabel {
+			clusters[label] = append(clusters[label], &(*points)[i])
+		}
+	}
+
+	planes := make(map[int]geometry.Vector)
+	largest := -1
+	for label, clusterPoints := range clusters {
+		if len(clusterPoints) >= minClusterSize {
+			planes[label] = FitPlane(clusterPoints...)
+		}
+		if largest == -1 || len(clusterPoints) > len(clusters[largest]) || (len(clusterPoints) == len(clusters[largest]) && label < largest) {
+			largest = label
+		}
+	}
+	if len(planes) == 0 && largest != -1 {
+		planes[largest] = FitPlane(clusters[largest]...)
+	}
+	return planes
+}
+
+// Returns the label of the nearest plane to the given point or the outlier label if the
+// distance to the nearest plane is larger than the outlier distance (and it isn't 0)
+func nearestPlane(planes map[int]geometry.Vector, point *geometry.Vector, outlierDistance float64) int {
+	// iterate in the order of the labels s.t. ties are always broken the same way
+	labels := make([]int, 0, len(planes))
+	for label := range planes {
+		labels = append(labels, label)
+	}
+	sort.Ints(labels)
+
+	best, bestDistance := OutlierLabel, math.Inf(1)
+	for _, label := range labels {
+		plane := planes[label]
+		if d := geometry.DistFromPlane(&plane, point); d < bestDistance {
+			best, bestDistance = label, d
+		}
+	}
+	if outlierDistance > 0 && bestDistance > outlierDistance {
+		return OutlierLabel
+	}
+	return best
+}
+
+// Numbers the clusters by the order of their first element, outliers keep their label
+func relabel(partitioning algorithm.PartitioningArray) algorithm.PartitioningArray {
+	labels := make(map[int]int)
+	result := make(algorithm.PartitioningArray, len(partitioning))
+	for i, label := range partitioning {
+		if label == OutlierLabel {
+			result[i] = OutlierLabel
+			continue
+		}
+		newLabel, ok := labels[label]
+		if !ok {
+			newLabel = len(labels)
+			labels[label] = newLabel
+		}
+		result[i] = newLabel
+	}
+	return result
+}
@@ -0,0 +1,63 @@
+package partitioning3D
+
+import (
+	"testing"
+
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/algorithm"
+	g "github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/geometry"
+	"github.com/stretchr/testify/assert"
+)
+
+var refinementPoints = []g.Vector{
+	// points on the xy-plane
+	{X: 1, Y: 0, Z: 0}, {X: 0, Y: 1, Z: 0}, {X: 1, Y: 1, Z: 0}, {X: -1, Y: 2, Z: 0},
+	// points on the yz-plane
+	{X: 0, Y: 1, Z: 1}, {X: 0, Y: -1, Z: 2}, {X: 0, Y: 3, Z: 1}, {X: 0, Y: 2, Z: -1},
+	// an outlier
+	{X: 5, Y: 5, Z: 5},
+}
+
+func TestRefine(t *testing.T) {
+	// the fourth point is assigned to the wrong cluster and the outlier is in its own cluster
+	partitioning := algorithm.PartitioningArray{0, 0, 0, 1, 1, 1, 1, 1, 2}
+
+	refined := Refine(&refinementPoints, partitioning, RefinementParameters{OutlierDistance: 0.5})
+	assert.Equal(t, algorithm.PartitioningArray{0, 0, 0, 0, 1, 1, 1, 1, OutlierLabel}, refined)
+	assert.Equal(t, algorithm.PartitioningArray{0, 0, 0, 1, 1, 1, 1, 1, 2}, partitioning, "The input shouldn't be modified")
+
+	refined = Refine(&refinementPoints, partitioning, RefinementParameters{})
+	assert.NotEqual(t, OutlierLabel, refined[8], "Without an outlier distance there are no outliers")
+	assert.Equal(t, 2, len(distinct(refined)), "The cluster of the outlier is too small and is merged away")
+
+	// after one iteration only the outlier is left in its cluster
+	partitioning = algorithm.PartitioningArray{0, 0, 0, 2, 1, 1, 1, 2, 2}
+	refined = Refine(&refinementPoints, partitioning, RefinementParameters{MaxIterations: 1})
+	assert.Equal(t, 2, len(distinct(refined)), "Clusters which are too small after the last iteration are dissolved")
+
+	assert.Panics(t, func() { Refine(&refinementPoints, algorithm.PartitioningArray{0}, RefinementParameters{}) })
+}
+
+func TestWithRefinement(t *testing.T) {
+	// the fourth point and the outlier are left in singleton clusters
+	partialPlanes := func(input *[]g.Vector, calc algorithm.CostCalculator[g.Vector]) algorithm.PartitioningArray {
+		return algorithm.PartitioningArray{0, 0, 0, 2, 1, 1, 1, 1, 3}
+	}
+
+	refined := WithRefinement(partialPlanes, RefinementParameters{OutlierDistance: 0.5})(&refinementPoints, CostCalculator{})
+	assert.Equal(t, algorithm.PartitioningArray{0, 0, 0, 0, 1, 1, 1, 1, OutlierLabel}, refined,
+		"The fourth point is merged into the xy-plane and the outlier is removed")
+
+	// without an outlier distance the outlier tilts the plane it's merged into
+	refined = WithRefinement(partialPlanes, RefinementParameters{})(&refinementPoints, CostCalculator{})
+	assert.Equal(t, 2, len(distinct(refined)), "The singletons are merged into the two planes")
+	assert.Equal(t, refined[0], refined[3])
+	assert.Equal(t, refined[4], refined[5])
+}
+
+func distinct(partitioning algorithm.PartitioningArray) map[int]bool {
+	labels := make(map[int]bool)
+	for _, label := range partitioning {
+		labels[label] = true
+	}
+	return labels
+}
@@ -2,6 +2,8 @@ package evaluation
 
 import (
 	"flag"
+	"fmt"
+	"math/rand"
 	"testing"
 
 	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/algorithm"
@@ -28,3 +30,22 @@ func BenchmarkAlgorithm(b *testing.B) {
 		algorithm(&testData.Points, &calc)
 	}
 }
+
+// Benchmarks the greedy joining algorithm for a growing number of points per plane. The
+// data is generated from 3 planes with a fixed seed, s.t. runs of different versions of
+// the algorithm can be compared.
+func BenchmarkGreedyJoiningPointsPerPlane(b *testing.B) {
+	noise := utils.NormalDist{Mean: 0, Stddev: 0.01}
+	calc := partitioning3D.CostCalculator{Threshold: 3 * noise.Stddev, Amplification: 1 / noise.Stddev}
+
+	for _, pointsPerPlane := range []int{5, 10, 20, 40} {
+		rand.Seed(int64(pointsPerPlane))
+		testData := GenerateDataWithNoise(3, pointsPerPlane, noise)
+
+		b.Run(fmt.Sprintf("pointsPerPlane=%d", pointsPerPlane), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				algorithm.GreedyJoining[geometry.Vector](&testData.Points, &calc)
+			}
+		})
+	}
+}
@@ -3,6 +3,9 @@ package evaluation
 import (
 	"flag"
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/algorithm"
@@ -12,7 +15,18 @@ import (
 )
 
 var threshold, amplification, mean, stddev *float64
-var numOfPlanes, pointsPerPlane *int
+var numOfPlanes, pointsPerPlane, neighbors *int
+var affine *bool
+var costShape, residual, affineMode *string
+var costScale *float64
+var maxOffset *float64
+var refine *bool
+var outlierDistance *float64
+var minClusterSize, refineIterations *int
+var outliers, noiseMinSize *int
+var outlierFraction, tangentialStddev, degreesOfFreedom, patchRadius *float64
+var planeSizes *string
+var shuffle *bool
 
 func init() {
 	threshold = flag.Float64("threshold", 1.0, "The threshold for the cost calculation")
@@ -22,6 +36,101 @@ func init() {
 
 	numOfPlanes = flag.Int("numberOfPlanes", 5, "How many planes should be used to sample data points")
 	pointsPerPlane = flag.Int("pointsPerPlane", 5, "How many points per plane should be sampled")
+	planeSizes = flag.String("planeSizes", "", "A comma separated list with the number of points of every plane, overrides numberOfPlanes and pointsPerPlane")
+	shuffle = flag.Bool("shuffle", false, "If true the points are shuffled, s.t. they're not ordered by their plane")
+
+	affine = flag.Bool("affine", false, "If true the planes don't have to go through the origin")
+	maxOffset = flag.Float64("maxOffset", 1.0, "The maximum distance of an affine plane to the origin")
+	neighbors = flag.Int("neighbors", 5, "How many nearest neighbors the cost calculation for affine planes uses")
+	affineMode = flag.String("affineMode", string(partitioning3D.QuadrupleMode), "How the affine costs are computed from the neighbors, one of quadruples or neighborFit")
+
+	costShape = flag.String("costShape", "linear", "How the residual is transformed into costs: linear, logistic, hinge or truncatedQuadratic")
+	residual = flag.String("residual", "max", "How the distances of the points to the plane are combined: max, rms or sumOfSquares")
+	costScale = flag.Float64("costScale", 0, "The scale of the logistic, hinge and truncated quadratic cost shape, if 0 the threshold is used")
+
+	outliers = flag.Int("outliers", 0, "How many uniformly distributed outliers are added to the test data")
+	outlierFraction = flag.Float64("outlierFraction", 0, "The fraction of all points that are uniformly distributed outliers in the cube [-1,1]³")
+	tangentialStddev = flag.Float64("tangentialStddev", 0, "The standard deviation of the noise within the planes")
+	degreesOfFreedom = flag.Float64("degreesOfFreedom", 0, "If positive the noise along the normal is Student-t distributed with these degrees of freedom")
+	patchRadius = flag.Float64("patchRadius", 0, "If positive the points are sampled from a patch with this radius on every plane")
+	noiseMinSize = flag.Int("noiseMinSize", 0, "Partitions with fewer points are labeled as noise")
+
+	refine = flag.Bool("refine", false, "If true the partitioning is refined by refitting planes and reassigning the points to their nearest plane")
+	outlierDistance = flag.Float64("outlierDistance", 0, "Points which are farther away from every plane are outliers after the refinement, if 0 there are no outliers")
+	minClusterSize = flag.Int("minClusterSize", partitioning3D.DefaultRefinementParameters.MinClusterSize, "Clusters with fewer points are dissolved by the refinement")
+	refineIterations = flag.Int("refineIterations", partitioning3D.DefaultRefinementParameters.MaxIterations, "How often the refinement refits the planes at most")
+}
+
+// Generates test data according to the command-line arguments
+func generateTestData() TestData {
+	noise := utils.NormalDist{Mean: *mean, Stddev: *stddev}
+	var testData TestData
+	if *affine {
+		testData = GenerateAffineDataWithNoise(*numOfPlanes, *pointsPerPlane, *maxOffset, noise)
+	} else {
+		testData = GenerateDataWithOptions(sizesFromFlags(), GeneratorOptions{
+			Noise:           geometry.NoiseModel{Normal: noise, DegreesOfFreedom: *degreesOfFreedom, Tangential: *tangentialStddev},
+			OutlierFraction: *outlierFraction,
+			PatchRadius:     *patchRadius,
+		})
+	}
+	AddUniformOutliers(&testData, *outliers)
+	if *shuffle {
+		ShufflePoints(&testData)
+	}
+	return testData
+}
+
+// Returns the number of points of every plane according to the command-line arguments
+func sizesFromFlags() []int {
+	if *planeSizes == "" {
+		return EqualPlaneSizes(*numOfPlanes, *pointsPerPlane)
+	}
+	var sizes []int
+	for _, size := range strings.Split(*planeSizes, ",") {
+		value, err := strconv.Atoi(strings.TrimSpace(size))
+		if err != nil {
+			panic(fmt.Sprintf("The plane size %s is not an integer", size))
+		}
+		sizes = append(sizes, value)
+	}
+	return sizes
+}
+
+// Returns the given algorithm with a refinement and noise labels if they were requested by
+// the command-line arguments
+func withRefinement(partitioningAlgorithm algorithm.PartitioningAlgorithm[geometry.Vector]) algorithm.PartitioningAlgorithm[geometry.Vector] {
+	if *refine {
+		partitioningAlgorithm = partitioning3D.WithRefinement(partitioningAlgorithm, partitioning3D.RefinementParameters{
+			MaxIterations:   *refineIterations,
+			OutlierDistance: *outlierDistance,
+			MinClusterSize:  *minClusterSize,
+		})
+	}
+	if *noiseMinSize > 0 {
+		partitioningAlgorithm = algorithm.WithNoiseLabel(partitioningAlgorithm, *noiseMinSize)
+	}
+	return partitioningAlgorithm
+}
+
+// Returns the cost calculator for the given test data according to the command-line arguments
+func createCostCalculator(testData *TestData) algorithm.CostCalculator[geometry.Vector] {
+	if *affine {
+		mode, err := partitioning3D.ParseAffineMode(*affineMode)
+		if err != nil {
+			panic(err)
+		}
+		calc := partitioning3D.CreateAffineCostCalculator(&testData.Points, *threshold, *amplification, *neighbors)
+		calc.Mode = mode
+		return calc
+	}
+	return partitioning3D.CostCalculator{
+		Threshold:     *threshold,
+		Amplification: *amplification,
+		Shape:         partitioning3D.CostShape(*costShape),
+		Residual:      partitioning3D.Residual(*residual),
+		Scale:         *costScale,
+	}
 }
 
 func TestEvalAlgorithm(t *testing.T) {
@@ -29,11 +138,18 @@ func TestEvalAlgorithm(t *testing.T) {
 	if *algorithm1 == "" {
 		return
 	}
-	testData := GenerateDataWithNoise(*numOfPlanes, *pointsPerPlane, utils.NormalDist{Mean: *mean, Stddev: *stddev})
-	algorithm := algorithm.AlgorithmStringToFunc[geometry.Vector](*algorithm1)
-	eval := EvaluateAlgorithm(algorithm, partitioning3D.CostCalculator{Threshold: *threshold, Amplification: *amplification}, &testData)
+	var testData TestData
+	if *inputFile == "" {
+		testData = generateTestData()
+	} else {
+		var err error
+		if testData, err = LoadLabeledTestData(*inputFile, *affine); err != nil {
+			t.Fatal(err)
+		}
+	}
+	algorithm := withRefinement(algorithm.AlgorithmStringToFunc[geometry.Vector](*algorithm1))
+	eval := EvaluateAlgorithm(algorithm, createCostCalculator(&testData), &testData)
 
-	fmt.Printf("%s on %d planes with %d points per plane gave the following results:\n", *algorithm1, *numOfPlanes, *pointsPerPlane)
-	fmt.Printf("\tnumber of planes error: %f%%\n\taccuracy: %f%%\n\tfalse positives: %f%%\n\tfalse negatives: %f%%\n",
-		eval.NumOfPlanesError*100, eval.Accuracy*100, eval.FalsePositives*100, eval.FalseNegatives*100)
+	fmt.Printf("%s on %d planes with %v points per plane gave the following results:\n", *algorithm1, testData.NumOfPlanes, testData.PlaneSizes())
+	eval.Report(os.Stdout)
 }
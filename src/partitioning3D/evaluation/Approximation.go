@@ -0,0 +1,68 @@
+package evaluation
+
+import (
+	"time"
+
+	alg "github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/algorithm"
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/geometry"
+)
+
+// The output after comparing an approximate algorithm with an exact one on the same test data.
+// The objective is the sum of the triple costs within the partitions (lower is better), the
+// times are in milliseconds and the agreement is the ratio of point pairs that are either in
+// the same partition in both partitionings or in different partitions in both partitionings.
+type ApproximationEvaluation struct {
+	Exact                  Evaluation
+	Approximation          Evaluation
+	ExactObjective         float64
+	ApproximationObjective float64
+	ExactTime              int64
+	ApproximationTime      int64
+	Agreement              float64
+}
+
+// Runs the exact and the approximate algorithm on the given test data and evaluates the
+// trade-off between accuracy and runtime
+func EvaluateApproximation(exact, approximation alg.PartitioningAlgorithm[geometry.Vector],
+	costCalc alg.CostCalculator[geometry.Vector], testData *TestData) ApproximationEvaluation {
+
+	start := time.Now()
+	exactPart := exact(&testData.Points, costCalc)
+	exactTime := time.Since(start).Milliseconds()
+
+	start = time.Now()
+	approximatePart := approximation(&testData.Points, costCalc)
+	approximationTime := time.Since(start).Milliseconds()
+
+	return ApproximationEvaluation{
+		Exact:                  EvaluatePartitioning(exactPart, testData),
+		Approximation:          EvaluatePartitioning(approximatePart, testData),
+		ExactObjective:         alg.Objective(&testData.Points, costCalc, exactPart),
+		ApproximationObjective: alg.Objective(&testData.Points, costCalc, approximatePart),
+		ExactTime:              exactTime,
+		ApproximationTime:      approximationTime,
+		Agreement:              Agreement(exactPart, approximatePart),
+	}
+}
+
+// Returns the ratio of element pairs on which both partitionings agree, i.e. the pair is either
+// in the same partition in both partitionings or in different partitions in both partitionings.
+// This function panics if the partitionings have different lengths.
+func Agreement(part1, part2 alg.PartitioningArray) float64 {
+	if len(part1) != len(part2) {
+		panic("The partitionings must have the same length")
+	}
+	n := len(part1)
+	if n < 2 {
+		return 1
+	}
+	agreeing := 0
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if (part1[i] == part1[j]) == (part2[i] == part2[j]) {
+				agreeing++
+			}
+		}
+	}
+	return float64(agreeing) / (float64(n*(n-1)) / 2.0)
+}
@@ -0,0 +1,50 @@
+package evaluation
+
+import (
+	"flag"
+	"fmt"
+	"testing"
+
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/algorithm"
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/geometry"
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/partitioning3D"
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+var sampleBudget *int
+var sampleSeed *int64
+
+func init() {
+	sampleBudget = flag.Int("sampleBudget", 0, "How many triples the sampled greedy algorithm samples per join or move")
+	sampleSeed = flag.Int64("sampleSeed", 0, "The seed for the sampling of the sampled greedy algorithm")
+}
+
+func TestAgreement(t *testing.T) {
+	assert.Equal(t, 1.0, Agreement(algorithm.PartitioningArray{0, 0, 1}, algorithm.PartitioningArray{5, 5, 3}))
+	assert.InDelta(t, 1.0/3.0, Agreement(algorithm.PartitioningArray{0, 0, 1}, algorithm.PartitioningArray{0, 1, 1}), delta)
+	assert.Panics(t, func() { Agreement(algorithm.PartitioningArray{0}, algorithm.PartitioningArray{0, 1}) })
+}
+
+// Compares the sampled greedy algorithm with the given sample budget to the exact greedy
+// moving algorithm. Run it with e.g. `-sampleBudget 50 -stddev 0.01 -threshold 0.03 -amplification 100`.
+func TestEvalApproximation(t *testing.T) {
+	flag.Parse()
+	if *sampleBudget == 0 {
+		return
+	}
+	testData := GenerateDataWithNoise(*numOfPlanes, *pointsPerPlane, utils.NormalDist{Mean: *mean, Stddev: *stddev})
+	calc := partitioning3D.CostCalculator{Threshold: *threshold, Amplification: *amplification}
+	approximation := algorithm.SampledGreedy[geometry.Vector](algorithm.SamplingParameters{
+		Budget:     *sampleBudget,
+		Candidates: algorithm.DefaultSamplingParameters.Candidates,
+		Seed:       *sampleSeed,
+	})
+	eval := EvaluateApproximation(algorithm.GreedyMoving[geometry.Vector], approximation, calc, &testData)
+
+	fmt.Printf("SampledGreedy with a budget of %d compared to GreedyMoving on %d planes with %d points per plane:\n",
+		*sampleBudget, *numOfPlanes, *pointsPerPlane)
+	fmt.Printf("\taccuracy: %f%% (exact: %f%%)\n\tobjective: %f (exact: %f)\n\ttime: %dms (exact: %dms)\n\tagreement: %f%%\n",
+		eval.Approximation.Accuracy*100, eval.Exact.Accuracy*100, eval.ApproximationObjective, eval.ExactObjective,
+		eval.ApproximationTime, eval.ExactTime, eval.Agreement*100)
+}
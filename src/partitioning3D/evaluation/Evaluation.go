@@ -12,6 +12,8 @@ import (
 // The output after an evaluation of an algorithm
 // A `positive` is an edge in the multicut, so an edge which was cut.
 // Analogously, an edge which was not cut, is a negative.
+// Points which are labeled as noise and outliers of the test data are treated as
+// singleton sets for the edges.
 type Evaluation struct {
 	NumOfPlanesError float64
 	Accuracy         float64
@@ -21,6 +23,29 @@ type Evaluation struct {
 	FalsePositives   int
 	FalseNegatives   int
 	ComputedPlanes   []geometry.Vector
+	ComputedOffsets  []float64 // The offsets of the computed planes if the test data has affine planes
+	NoisePoints      int       // How many points were labeled as noise
+	Outliers         int       // How many points of the test data are outliers
+	DetectedOutliers int       // How many outliers were labeled as noise
+	Metrics          ClusteringMetrics
+	PlaneRecovery    PlaneRecovery // The comparison of the computed planes of clusters with at least MinPlanePoints points with the planes of the test data
+}
+
+// Returns the ratio of the points labeled as noise that are outliers, this is 1 if no point
+// was labeled as noise
+func (eval *Evaluation) NoisePrecision() float64 {
+	if eval.NoisePoints == 0 {
+		return 1
+	}
+	return float64(eval.DetectedOutliers) / float64(eval.NoisePoints)
+}
+
+// Returns the ratio of the outliers that were labeled as noise, this is 1 if there are no outliers
+func (eval *Evaluation) NoiseRecall() float64 {
+	if eval.Outliers == 0 {
+		return 1
+	}
+	return float64(eval.DetectedOutliers) / float64(eval.Outliers)
 }
 
 // Evaluate an algorithm by specifying the algorithm as string (the function name of the algorithm)
@@ -34,39 +59,41 @@ func Evaluate(algorithm string, costCalc alg.CostCalculator[geometry.Vector], nu
 
 // Evaluates a given algorithm with the given test data
 func EvaluateAlgorithm(algorithm alg.PartitioningAlgorithm[geometry.Vector], costCalc alg.CostCalculator[geometry.Vector], testData *TestData) Evaluation {
-	part := algorithm(&testData.Points, costCalc)
-	numOfPlanes := len(utils.ToSet(part))
+	return EvaluatePartitioning(algorithm(&testData.Points, costCalc), testData)
+}
+
+// Evaluates a partitioning that was computed for the points of the given test data
+func EvaluatePartitioning(part alg.PartitioningArray, testData *TestData) Evaluation {
+	// noise doesn't belong to a plane
+	numOfPlanes := 0
+	for _, label := range utils.ToSet(part) {
+		if label != alg.NoiseLabel {
+			numOfPlanes++
+		}
+	}
 	numOfPlanesError := math.Abs(float64(numOfPlanes-testData.NumOfPlanes)) / float64(testData.NumOfPlanes)
 	n := len(testData.Points)
 
-	pointsPerPlane := n / len(testData.Planes)
-	correctPartitioned := 0
-	tN := 0
-	tP := 0
-	fN := 0
-	fP := 0
-	for i := 0; i < n; i++ {
-		for j := i + 1; j < n; j++ {
-			samePartition := i/pointsPerPlane == j/pointsPerPlane
-			if samePartition && part[i] == part[j] {
-				correctPartitioned++
-				tN++
-			} else if !samePartition && part[i] != part[j] {
-				correctPartitioned++
-				tP++
-			} else if samePartition {
-				fP++
-			} else {
-				fN++
+	labels := make([]int, n)
+	noisePoints, detectedOutliers := 0, 0
+	for i := range labels {
+		labels[i] = testData.Label(i)
+		if part[i] == alg.NoiseLabel {
+			noisePoints++
+			if labels[i] == alg.NoiseLabel {
+				detectedOutliers++
 			}
 		}
 	}
-	totalEdges := (float64(n*(n-1)) / 2.0)
-	accuracy := float64(correctPartitioned) / totalEdges
+
+	metrics := ComputeClusteringMetrics(labels, part)
 
 	// compute planes
 	partitioning := make(map[int][]*geometry.Vector, numOfPlanes)
 	for i, partition := range part {
+		if partition == alg.NoiseLabel {
+			continue
+		}
 		_, ok := partitioning[partition]
 		if ok {
 			partitioning[partition] = append(partitioning[partition], &testData.Points[i])
@@ -76,17 +103,32 @@ func EvaluateAlgorithm(algorithm alg.PartitioningAlgorithm[geometry.Vector], cos
 	}
 
 	computedPlanes := make([]geometry.Vector, 0, numOfPlanes)
+	computedSizes := make([]int, 0, numOfPlanes)
+	var computedOffsets []float64
 	for _, points := range partitioning {
-		computedPlanes = append(computedPlanes, partitioning3D.FitPlane(points...))
+		computedSizes = append(computedSizes, len(points))
+		if testData.Offsets == nil {
+			computedPlanes = append(computedPlanes, partitioning3D.FitPlane(points...))
+		} else {
+			plane := partitioning3D.FitAffinePlane(points...)
+			computedPlanes = append(computedPlanes, plane.Normal)
+			computedOffsets = append(computedOffsets, plane.Offset)
+		}
 	}
 	return Evaluation{
 		NumOfPlanesError: numOfPlanesError,
-		Accuracy:         accuracy,
-		TotalEdges:       int(totalEdges),
-		TruePositives:    tP,
-		TrueNegatives:    tN,
-		FalsePositives:   fP,
-		FalseNegatives:   fN,
+		Accuracy:         metrics.RandIndex,
+		TotalEdges:       metrics.Edges.Total,
+		TruePositives:    metrics.Edges.TruePositives,
+		TrueNegatives:    metrics.Edges.TrueNegatives,
+		FalsePositives:   metrics.Edges.FalsePositives,
+		FalseNegatives:   metrics.Edges.FalseNegatives,
 		ComputedPlanes:   computedPlanes,
+		ComputedOffsets:  computedOffsets,
+		NoisePoints:      noisePoints,
+		Outliers:         testData.NumOfOutliers,
+		DetectedOutliers: detectedOutliers,
+		Metrics:          metrics,
+		PlaneRecovery:    CompareClusterPlanes(computedPlanes, computedSizes, testData.Planes),
 	}
 }
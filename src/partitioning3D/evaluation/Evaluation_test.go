@@ -5,6 +5,7 @@ import (
 
 	alg "github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/algorithm"
 	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/geometry"
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/utils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -62,3 +63,46 @@ func TestEvaluate(t *testing.T) {
 	assert.Equal(t, 1.0, evaluation1.Accuracy, "The first algorithm partitions everything correctly")
 	assert.InDelta(t, 0.9886868686868687, evaluation2.Accuracy, delta, "The second algorithm doesn't partition everything correct")
 }
+
+func TestEvaluateNoise(t *testing.T) {
+	testData := GenerateDataWithoutNoise(2, 3)
+	AddUniformOutliers(&testData, 2)
+
+	// one outlier is detected, the other one is joined with the second plane and one point
+	// of the first plane is labeled as noise
+	part := alg.PartitioningArray{0, 0, alg.NoiseLabel, 1, 1, 1, alg.NoiseLabel, 1}
+	eval := EvaluatePartitioning(part, &testData)
+
+	assert.Equal(t, 0.0, eval.NumOfPlanesError, "Noise isn't a plane")
+	assert.Equal(t, 28, eval.TotalEdges)
+	assert.Equal(t, 4, eval.TrueNegatives)
+	assert.Equal(t, 2, eval.FalsePositives)
+	assert.Equal(t, 3, eval.FalseNegatives)
+	assert.Equal(t, 19, eval.TruePositives)
+	assert.Equal(t, 2, eval.NoisePoints)
+	assert.Equal(t, 2, eval.Outliers)
+	assert.Equal(t, 1, eval.DetectedOutliers)
+	assert.Equal(t, 0.5, eval.NoisePrecision())
+	assert.Equal(t, 0.5, eval.NoiseRecall())
+	assert.Equal(t, 2, len(eval.ComputedPlanes))
+	assert.InDelta(t, float64(eval.TruePositives+eval.TrueNegatives)/float64(eval.TotalEdges), eval.Metrics.RandIndex, delta,
+		"The Rand index should be the accuracy")
+}
+
+func TestEvaluateUnequalSizes(t *testing.T) {
+	testData := GenerateDataWithNoiseAndSizes([]int{2, 6}, utils.NormalDist{Mean: 0, Stddev: 0})
+	ShufflePoints(&testData)
+
+	eval := EvaluatePartitioning(testData.Labels, &testData)
+	assert.Equal(t, 1.0, eval.Accuracy, "The ground truth labels should be evaluated as correct")
+	assert.InDelta(t, 1, eval.Metrics.AdjustedRandIndex, delta)
+	assert.InDelta(t, 0, eval.PlaneRecovery.MaxAngle, 0.0001, "Without noise the planes should be recovered exactly")
+	assert.Equal(t, 1, eval.PlaneRecovery.UnmatchedTruth, "The computed plane of the 2 points isn't compared")
+	assert.Equal(t, 0.0, eval.NumOfPlanesError)
+
+	part := make(alg.PartitioningArray, len(testData.Points))
+	eval = EvaluatePartitioning(part, &testData)
+	assert.Equal(t, 0.5, eval.NumOfPlanesError)
+	assert.Equal(t, 12, eval.FalseNegatives, "The 2*6 edges between the planes are not cut")
+	assert.Equal(t, 16, eval.TrueNegatives)
+}
@@ -0,0 +1,80 @@
+package evaluation
+
+import (
+	"errors"
+	"sort"
+
+	alg "github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/algorithm"
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/geometry"
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/partitioning3D"
+)
+
+// Creates test data out of points with ground truth labels, e.g. out of annotated scans.
+// The labels are renumbered to 0, 1, ... in ascending order of the given labels, the noise
+// label stays the same. As the true planes are unknown, the planes of the test data are the
+// planes which fit best to the points with the same label. If affine is true, these planes
+// don't have to go through the origin. The plane of a label with fewer than MinPlanePoints
+// points isn't determined by the data, so it's the zero vector (with offset 0) and it isn't
+// compared with the computed planes. This function panics if the number of labels doesn't
+// match the number of points.
+func TestDataFromLabels(points []geometry.Vector, labels []int, affine bool) TestData {
+	if len(points) != len(labels) {
+		panic("There must be one label for every point")
+	}
+
+	var uniqueLabels []int
+	seen := make(map[int]bool)
+	for _, label := range labels {
+		if label != alg.NoiseLabel && !seen[label] {
+			seen[label] = true
+			uniqueLabels = append(uniqueLabels, label)
+		}
+	}
+	sort.Ints(uniqueLabels)
+	newLabels := make(map[int]int, len(uniqueLabels))
+	for i, label := range uniqueLabels {
+		newLabels[label] = i
+	}
+
+	testData := TestData{NumOfPlanes: len(uniqueLabels), Points: points, Labels: make([]int, len(labels))}
+	planePoints := make([][]*geometry.Vector, len(uniqueLabels))
+	for i, label := range labels {
+		if label == alg.NoiseLabel {
+			testData.Labels[i] = alg.NoiseLabel
+			testData.NumOfOutliers++
+			continue
+		}
+		testData.Labels[i] = newLabels[label]
+		planePoints[newLabels[label]] = append(planePoints[newLabels[label]], &testData.Points[i])
+	}
+
+	for _, points := range planePoints {
+		if len(points) < MinPlanePoints {
+			testData.Planes = append(testData.Planes, geometry.Vector{})
+			if affine {
+				testData.Offsets = append(testData.Offsets, 0)
+			}
+		} else if affine {
+			plane := partitioning3D.FitAffinePlane(points...)
+			testData.Planes = append(testData.Planes, plane.Normal)
+			testData.Offsets = append(testData.Offsets, plane.Offset)
+		} else {
+			testData.Planes = append(testData.Planes, partitioning3D.FitPlane(points...))
+		}
+	}
+	return testData
+}
+
+// Loads test data out of a csv file with the columns x, y, z and label (see
+// partitioning3D.ParseLabeledPoints and TestDataFromLabels). An error is returned if the
+// file can't be parsed or has no label column.
+func LoadLabeledTestData(path string, affine bool) (TestData, error) {
+	points, labels, err := partitioning3D.ParseLabeledPoints(path)
+	if err != nil {
+		return TestData{}, err
+	}
+	if labels == nil {
+		return TestData{}, errors.New("The csv file has no label column")
+	}
+	return TestDataFromLabels(*points, labels, affine), nil
+}
@@ -0,0 +1,77 @@
+package evaluation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	alg "github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/algorithm"
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/geometry"
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTestDataFromLabels(t *testing.T) {
+	generated := GenerateDataWithNoiseAndSizes([]int{4, 6}, utils.NormalDist{Mean: 0, Stddev: 0})
+	AddUniformOutliers(&generated, 2)
+
+	// use other labels than the generated ones
+	labels := make([]int, len(generated.Labels))
+	for i, label := range generated.Labels {
+		if label == alg.NoiseLabel {
+			labels[i] = alg.NoiseLabel
+		} else {
+			labels[i] = 10 - 3*label
+		}
+	}
+	testData := TestDataFromLabels(generated.Points, labels, false)
+
+	assert.Equal(t, 2, testData.NumOfPlanes)
+	assert.Equal(t, 2, testData.NumOfOutliers)
+	assert.Equal(t, []int{6, 4}, testData.PlaneSizes(), "The labels should be renumbered in ascending order")
+	assert.Nil(t, testData.Offsets)
+	assert.InDelta(t, 0, PlaneAngle(generated.Planes[0], testData.Planes[1]), 0.0001, "The planes should fit the labeled points")
+	assert.InDelta(t, 0, PlaneAngle(generated.Planes[1], testData.Planes[0]), 0.0001, "The planes should fit the labeled points")
+
+	eval := EvaluatePartitioning(generated.Labels, &testData)
+	assert.Equal(t, 1.0, eval.Accuracy)
+
+	testData = TestDataFromLabels(generated.Points, labels, true)
+	assert.Equal(t, 2, len(testData.Offsets))
+
+	// a label with too few points doesn't have a true plane
+	labels[0] = 42
+	testData = TestDataFromLabels(generated.Points, labels, true)
+	assert.Equal(t, geometry.Vector{}, testData.Planes[2])
+	assert.Equal(t, 0.0, testData.Offsets[2])
+	eval = EvaluatePartitioning(generated.Labels, &testData)
+	assert.Equal(t, 2, len(eval.PlaneRecovery.Matches), "The unknown plane shouldn't be compared")
+	assert.Equal(t, 0, eval.PlaneRecovery.UnmatchedTruth)
+
+	assert.Panics(t, func() { TestDataFromLabels([]geometry.Vector{{}}, []int{}, false) })
+}
+
+func TestLoadLabeledTestData(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "labeled.csv")
+	content := "x,y,z,label\n1,0,0,7\n0,1,0,7\n1,1,0,7\n0,0,1,-1\n0,1,1,3\n0,2,1,3\n0,1,2,3\n2,2,2,5\n"
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0644))
+
+	testData, err := LoadLabeledTestData(path, false)
+	assert.Nil(t, err)
+	assert.Equal(t, []geometry.Vector{{X: 1, Y: 0, Z: 0}, {X: 0, Y: 1, Z: 0}, {X: 1, Y: 1, Z: 0}, {X: 0, Y: 0, Z: 1},
+		{X: 0, Y: 1, Z: 1}, {X: 0, Y: 2, Z: 1}, {X: 0, Y: 1, Z: 2}, {X: 2, Y: 2, Z: 2}}, testData.Points)
+	assert.Equal(t, []int{2, 2, 2, alg.NoiseLabel, 0, 0, 0, 1}, testData.Labels, "The labels should be renumbered in ascending order")
+	assert.Equal(t, 3, testData.NumOfPlanes)
+	assert.Equal(t, 1, testData.NumOfOutliers)
+	assert.InDelta(t, 0, PlaneAngle(geometry.Vector{X: 1, Y: 0, Z: 0}, testData.Planes[0]), 0.0001)
+	assert.Equal(t, geometry.Vector{}, testData.Planes[1], "The plane of a single point is unknown")
+	assert.InDelta(t, 0, PlaneAngle(geometry.Vector{X: 0, Y: 0, Z: 1}, testData.Planes[2]), 0.0001)
+
+	unlabeled := filepath.Join(t.TempDir(), "unlabeled.csv")
+	assert.Nil(t, os.WriteFile(unlabeled, []byte("x,y,z\n1,0,0\n"), 0644))
+	_, err = LoadLabeledTestData(unlabeled, false)
+	assert.NotNil(t, err, "A file without labels can't be loaded")
+
+	_, err = LoadLabeledTestData("does_not_exist.csv", false)
+	assert.NotNil(t, err)
+}
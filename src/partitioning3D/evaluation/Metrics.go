@@ -0,0 +1,244 @@
+package evaluation
+
+import (
+	"math"
+	"sort"
+
+	alg "github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/algorithm"
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/utils"
+)
+
+// Standard metrics which compare a computed clustering with the ground truth. The logarithms
+// of the information theoretic metrics are natural logarithms.
+type ClusteringMetrics struct {
+	RandIndex         float64 // The ratio of point pairs on which both clusterings agree
+	AdjustedRandIndex float64 // The Rand index corrected for chance, 1 for identical clusterings and around 0 for random ones
+	VI                float64 // The variation of information, which is VISplit + VIMerge
+	VISplit           float64 // The conditional entropy of the computed clusters given the true clusters, caused by splitting true clusters
+	VIMerge           float64 // The conditional entropy of the true clusters given the computed clusters, caused by merging true clusters
+	NMI               float64 // The mutual information normalized by the arithmetic mean of both entropies
+	Edges             EdgeCounts
+	Clusters          []ClusterMatch
+}
+
+// The numbers of the point pairs (edges) compared between both clusterings. A `positive` is an
+// edge which is cut by the computed clustering, a `true` edge is cut by both or by none of the
+// clusterings.
+type EdgeCounts struct {
+	Total          int
+	TruePositives  int
+	TrueNegatives  int
+	FalsePositives int
+	FalseNegatives int
+}
+
+// A true cluster and the computed cluster it's matched with
+type ClusterMatch struct {
+	Truth     int     // The label of the true cluster
+	Predicted int     // The label of the matched computed cluster or the noise label if it isn't matched
+	Size      int     // The number of points in the true cluster
+	Overlap   int     // The number of points which are in both clusters
+	Precision float64 // The ratio of the points of the computed cluster which are in the true cluster
+	Recall    float64 // The ratio of the points of the true cluster which are in the computed cluster
+}
+
+// Computes the clustering metrics of the predicted labels with respect to the true labels.
+// Points with the noise label (in either of the label arrays) are treated as singleton
+// clusters, they are counted separately from the contingency table of the other clusters.
+// The true clusters are matched one-to-one with the computed clusters s.t. the sum
+// of their overlaps is maximal, the matches are ordered by the labels of the true clusters.
+// This function panics if the label arrays have different lengths.
+func ComputeClusteringMetrics(truth, predicted []int) ClusteringMetrics {
+	if len(truth) != len(predicted) {
+		panic("The label arrays must have the same length")
+	}
+	n := len(truth)
+	if n == 0 {
+		return ClusteringMetrics{RandIndex: 1, AdjustedRandIndex: 1, NMI: 1, Clusters: []ClusterMatch{}}
+	}
+
+	truthIds, truthLabels := denseLabels(truth)
+	predictedIds, predictedLabels := denseLabels(predicted)
+	contingency := make([][]int, len(truthLabels))
+	for i := range contingency {
+		contingency[i] = make([]int, len(predictedLabels))
+	}
+	// the sizes contain the points which are noise in the other clustering as well
+	truthSizes := make([]int, len(truthLabels))
+	predictedSizes := make([]int, len(predictedLabels))
+	truthNoise, predictedNoise := 0, 0
+	for i := range truth {
+		if truthIds[i] == -1 {
+			truthNoise++
+		} else {
+			truthSizes[truthIds[i]]++
+		}
+		if predictedIds[i] == -1 {
+			predictedNoise++
+		} else {
+			predictedSizes[predictedIds[i]]++
+		}
+		if truthIds[i] != -1 && predictedIds[i] != -1 {
+			contingency[truthIds[i]][predictedIds[i]]++
+		}
+	}
+
+	metrics := ClusteringMetrics{}
+	metrics.RandIndex, metrics.AdjustedRandIndex = randIndices(contingency, truthSizes, predictedSizes, n)
+	metrics.Edges = countEdges(contingency, truthSizes, predictedSizes, n)
+
+	truthEntropy := entropy(truthSizes, truthNoise, n)
+	predictedEntropy := entropy(predictedSizes, predictedNoise, n)
+	mutualInformation := 0.0
+	for i, row := range contingency {
+		for j, count := range row {
+			if count > 0 {
+				mutualInformation += float64(count) / float64(n) *
+					math.Log(float64(count)*float64(n)/(float64(truthSizes[i])*float64(predictedSizes[j])))
+			}
+		}
+	}
+	// every noise point is a singleton cluster, so it's in a cell of the contingency table
+	// which only contains this point
+	for i := range truth {
+		if truthIds[i] != -1 && predictedIds[i] != -1 {
+			continue
+		}
+		truthSize, predictedSize := 1, 1
+		if truthIds[i] != -1 {
+			truthSize = truthSizes[truthIds[i]]
+		}
+		if predictedIds[i] != -1 {
+			predictedSize = predictedSizes[predictedIds[i]]
+		}
+		mutualInformation += 1 / float64(n) * math.Log(float64(n)/(float64(truthSize)*float64(predictedSize)))
+	}
+	metrics.VISplit = math.Max(0, predictedEntropy-mutualInformation)
+	metrics.VIMerge = math.Max(0, truthEntropy-mutualInformation)
+	metrics.VI = metrics.VISplit + metrics.VIMerge
+	if truthEntropy+predictedEntropy == 0 {
+		metrics.NMI = 1
+	} else {
+		metrics.NMI = 2 * mutualInformation / (truthEntropy + predictedEntropy)
+	}
+
+	metrics.Clusters = matchClusters(contingency, truthLabels, predictedLabels, truthSizes, predictedSizes)
+	return metrics
+}
+
+// Maps the labels to the indices 0, 1, ... in the order of their first occurrence. Noise
+// gets the index -1. Returns the index of every element and the label of every index.
+func denseLabels(labels []int) ([]int, []int) {
+	indices := make(map[int]int)
+	ids := make([]int, len(labels))
+	var uniqueLabels []int
+	for i, label := range labels {
+		if label == alg.NoiseLabel {
+			ids[i] = -1
+			continue
+		}
+		id, ok := indices[label]
+		if !ok {
+			id = len(uniqueLabels)
+			indices[label] = id
+			uniqueLabels = append(uniqueLabels, label)
+		}
+		ids[i] = id
+	}
+	return ids, uniqueLabels
+}
+
+// Returns the number of pairs out of n elements
+func pairs(n int) float64 {
+	return float64(n) * float64(n-1) / 2
+}
+
+// Computes the Rand index and the adjusted Rand index from the contingency table
+func randIndices(contingency [][]int, truthSizes, predictedSizes []int, n int) (float64, float64) {
+	sameInBoth := 0.0
+	for _, row := range contingency {
+		for _, count := range row {
+			sameInBoth += pairs(count)
+		}
+	}
+	sameInTruth := utils.MapSum(truthSizes, pairs)
+	sameInPredicted := utils.MapSum(predictedSizes, pairs)
+	total := pairs(n)
+	if total == 0 {
+		return 1, 1
+	}
+
+	randIndex := (total + 2*sameInBoth - sameInTruth - sameInPredicted) / total
+
+	expected := sameInTruth * sameInPredicted / total
+	maximum := (sameInTruth + sameInPredicted) / 2
+	if maximum == expected {
+		// both clusterings are trivial (e.g. only singletons), so they can only be identical
+		return randIndex, 1
+	}
+	return randIndex, (sameInBoth - expected) / (maximum - expected)
+}
+
+// Counts the edges which are cut or not cut by the clusterings from the contingency table
+func countEdges(contingency [][]int, truthSizes, predictedSizes []int, n int) EdgeCounts {
+	sameInBoth, sameInTruth, sameInPredicted := 0, 0, 0
+	for _, row := range contingency {
+		for _, count := range row {
+			sameInBoth += count * (count - 1) / 2
+		}
+	}
+	for _, size := range truthSizes {
+		sameInTruth += size * (size - 1) / 2
+	}
+	for _, size := range predictedSizes {
+		sameInPredicted += size * (size - 1) / 2
+	}
+	edges := EdgeCounts{
+		Total:          n * (n - 1) / 2,
+		TrueNegatives:  sameInBoth,
+		FalsePositives: sameInTruth - sameInBoth,
+		FalseNegatives: sameInPredicted - sameInBoth,
+	}
+	edges.TruePositives = edges.Total - edges.TrueNegatives - edges.FalsePositives - edges.FalseNegatives
+	return edges
+}
+
+// Computes the entropy of a clustering with the given cluster sizes and the given number of
+// singleton clusters
+func entropy(sizes []int, singletons, n int) float64 {
+	result := float64(singletons) / float64(n) * math.Log(float64(n))
+	for _, size := range sizes {
+		if size > 0 {
+			p := float64(size) / float64(n)
+			result -= p * math.Log(p)
+		}
+	}
+	return result
+}
+
+// Matches the true clusters with the computed clusters s.t. the sum of the overlaps is
+// maximal. The contingency table doesn't contain noise, so noise can't be matched.
+func matchClusters(contingency [][]int, truthLabels, predictedLabels, truthSizes, predictedSizes []int) []ClusterMatch {
+	cost := make([][]float64, len(truthLabels))
+	for i, row := range contingency {
+		cost[i] = make([]float64, len(predictedLabels))
+		for j, count := range row {
+			cost[i][j] = -float64(count)
+		}
+	}
+	assignment := utils.Hungarian(cost)
+
+	matches := []ClusterMatch{}
+	for i, label := range truthLabels {
+		match := ClusterMatch{Truth: label, Predicted: alg.NoiseLabel, Size: truthSizes[i]}
+		if j := assignment[i]; j != -1 && contingency[i][j] > 0 {
+			match.Predicted = predictedLabels[j]
+			match.Overlap = contingency[i][j]
+			match.Precision = float64(match.Overlap) / float64(predictedSizes[j])
+			match.Recall = float64(match.Overlap) / float64(match.Size)
+		}
+		matches = append(matches, match)
+	}
+	sort.Slice(matches, func(a, b int) bool { return matches[a].Truth < matches[b].Truth })
+	return matches
+}
@@ -0,0 +1,75 @@
+package evaluation
+
+import (
+	"testing"
+
+	alg "github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/algorithm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeClusteringMetrics(t *testing.T) {
+	truth := []int{0, 0, 0, 1, 1, 1, 2, 2}
+	predicted := []int{5, 5, 7, 7, 7, 7, 9, alg.NoiseLabel}
+	metrics := ComputeClusteringMetrics(truth, predicted)
+
+	assert.InDelta(t, 0.7857142857142857, metrics.RandIndex, delta)
+	assert.InDelta(t, 0.42857142857142855, metrics.AdjustedRandIndex, delta)
+	assert.InDelta(t, 0.41197960825054103, metrics.VISplit, delta)
+	assert.InDelta(t, 0.28116757230940415, metrics.VIMerge, delta)
+	assert.InDelta(t, metrics.VISplit+metrics.VIMerge, metrics.VI, delta)
+	assert.InDelta(t, 0.6980018100523221, metrics.NMI, delta)
+	assert.Equal(t, EdgeCounts{Total: 28, TruePositives: 18, TrueNegatives: 4, FalsePositives: 3, FalseNegatives: 3}, metrics.Edges)
+	assert.Equal(t, []ClusterMatch{
+		{Truth: 0, Predicted: 5, Size: 3, Overlap: 2, Precision: 1, Recall: 2.0 / 3.0},
+		{Truth: 1, Predicted: 7, Size: 3, Overlap: 3, Precision: 0.75, Recall: 1},
+		{Truth: 2, Predicted: 9, Size: 2, Overlap: 1, Precision: 1, Recall: 0.5},
+	}, metrics.Clusters)
+}
+
+func TestComputeClusteringMetricsIdentical(t *testing.T) {
+	truth := []int{0, 0, 1, 1, 1, alg.NoiseLabel}
+	metrics := ComputeClusteringMetrics(truth, []int{3, 3, 2, 2, 2, alg.NoiseLabel})
+
+	assert.Equal(t, 1.0, metrics.RandIndex)
+	assert.InDelta(t, 1, metrics.AdjustedRandIndex, delta)
+	assert.InDelta(t, 0, metrics.VI, delta)
+	assert.InDelta(t, 1, metrics.NMI, delta)
+	assert.Equal(t, 2, len(metrics.Clusters), "Noise isn't a cluster")
+	assert.Equal(t, ClusterMatch{Truth: 1, Predicted: 2, Size: 3, Overlap: 3, Precision: 1, Recall: 1}, metrics.Clusters[1])
+
+	// a cluster which is completely labeled as noise isn't matched
+	metrics = ComputeClusteringMetrics([]int{0, 0, 1, 1}, []int{0, 0, alg.NoiseLabel, alg.NoiseLabel})
+	assert.Equal(t, ClusterMatch{Truth: 1, Predicted: alg.NoiseLabel, Size: 2}, metrics.Clusters[1])
+
+	assert.Panics(t, func() { ComputeClusteringMetrics([]int{0}, []int{0, 1}) })
+}
+
+func TestComputeClusteringMetricsWithNoise(t *testing.T) {
+	truth := []int{0, 0, 0, 1, 1, alg.NoiseLabel, alg.NoiseLabel, 2, 2, alg.NoiseLabel}
+	predicted := []int{4, 4, alg.NoiseLabel, 5, 4, 5, alg.NoiseLabel, alg.NoiseLabel, 6, 6}
+	metrics := ComputeClusteringMetrics(truth, predicted)
+
+	// noise is the same as singleton clusters with unique labels
+	singletons := func(labels []int, first int) []int {
+		result := make([]int, len(labels))
+		for i, label := range labels {
+			result[i] = label
+			if label == alg.NoiseLabel {
+				result[i] = first + i
+			}
+		}
+		return result
+	}
+	expected := ComputeClusteringMetrics(singletons(truth, 100), singletons(predicted, 200))
+	assert.InDelta(t, expected.RandIndex, metrics.RandIndex, delta)
+	assert.InDelta(t, expected.AdjustedRandIndex, metrics.AdjustedRandIndex, delta)
+	assert.InDelta(t, expected.VISplit, metrics.VISplit, delta)
+	assert.InDelta(t, expected.VIMerge, metrics.VIMerge, delta)
+	assert.InDelta(t, expected.NMI, metrics.NMI, delta)
+	assert.Equal(t, expected.Edges, metrics.Edges)
+	assert.Equal(t, []ClusterMatch{
+		{Truth: 0, Predicted: 4, Size: 3, Overlap: 2, Precision: 2.0 / 3.0, Recall: 2.0 / 3.0},
+		{Truth: 1, Predicted: 5, Size: 2, Overlap: 1, Precision: 0.5, Recall: 0.5},
+		{Truth: 2, Predicted: 6, Size: 2, Overlap: 1, Precision: 0.5, Recall: 0.5},
+	}, metrics.Clusters, "Noise isn't matched")
+}
@@ -0,0 +1,102 @@
+package evaluation
+
+import (
+	"math"
+
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/geometry"
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/utils"
+)
+
+// How well the true planes were recovered by the computed planes. All angles are in degrees.
+type PlaneRecovery struct {
+	Matches           []PlaneMatch
+	MeanAngle         float64 // The mean angle of the matched planes, 0 if no plane is matched
+	MaxAngle          float64 // The largest angle of the matched planes
+	UnmatchedTruth    int     // How many true planes weren't matched with a computed plane
+	UnmatchedComputed int     // How many computed planes weren't matched with a true plane
+}
+
+// A true plane and the computed plane it's matched with
+type PlaneMatch struct {
+	Truth    int     // The index of the true plane
+	Computed int     // The index of the computed plane
+	Angle    float64 // The angle between the normal vectors of both planes in degrees
+}
+
+// The minimum number of points of a computed cluster whose plane is compared with the true
+// planes. The plane of fewer points only depends on these points and not on the data.
+const MinPlanePoints = 3
+
+// Returns the angle between the two planes through the origin with the given normal vectors
+// in degrees. The orientation of the normal vectors doesn't matter, so the angle is at most 90°.
+func PlaneAngle(normal1, normal2 geometry.Vector) float64 {
+	cos := math.Abs(normal1.X*normal2.X+normal1.Y*normal2.Y+normal1.Z*normal2.Z) / (normal1.GetLength() * normal2.GetLength())
+	return math.Acos(math.Min(cos, 1)) * 180 / math.Pi
+}
+
+// Matches the computed planes with the true planes by the Hungarian algorithm, s.t. the sum
+// of the angles between the matched planes is minimal. If there are more computed than true
+// planes (or the other way around), some computed (true) planes aren't matched. The matches
+// are ordered by the index of the true plane.
+func ComparePlanes(computed, truth []geometry.Vector) PlaneRecovery {
+	angles := make([][]float64, len(truth))
+	for i := range truth {
+		angles[i] = make([]float64, len(computed))
+		for j := range computed {
+			angles[i][j] = PlaneAngle(truth[i], computed[j])
+		}
+	}
+
+	recovery := PlaneRecovery{Matches: []PlaneMatch{}}
+	if len(computed) > 0 {
+		for i, j := range utils.Hungarian(angles) {
+			if j != -1 {
+				recovery.Matches = append(recovery.Matches, PlaneMatch{Truth: i, Computed: j, Angle: angles[i][j]})
+			}
+		}
+	}
+
+	for _, match := range recovery.Matches {
+		recovery.MeanAngle += match.Angle
+		recovery.MaxAngle = math.Max(recovery.MaxAngle, match.Angle)
+	}
+	if len(recovery.Matches) > 0 {
+		recovery.MeanAngle /= float64(len(recovery.Matches))
+	}
+	recovery.UnmatchedTruth = len(truth) - len(recovery.Matches)
+	recovery.UnmatchedComputed = len(computed) - len(recovery.Matches)
+	return recovery
+}
+
+// Same as ComparePlanes but sizes contains the number of points to which every computed plane
+// was fitted. Computed planes of fewer than MinPlanePoints points aren't compared, so they're
+// neither matched nor unmatched. The same holds for true planes which are unknown (the zero
+// vector, see TestDataFromLabels). The indices of the matches refer to all computed and true
+// planes. This function panics if there isn't one size for every computed plane.
+func CompareClusterPlanes(computed []geometry.Vector, sizes []int, truth []geometry.Vector) PlaneRecovery {
+	if len(computed) != len(sizes) {
+		panic("There must be one size for every computed plane")
+	}
+	var compared []geometry.Vector
+	var indices []int
+	for i, plane := range computed {
+		if sizes[i] >= MinPlanePoints {
+			compared = append(compared, plane)
+			indices = append(indices, i)
+		}
+	}
+	var knownTruth []geometry.Vector
+	var truthIndices []int
+	for i, plane := range truth {
+		if plane != (geometry.Vector{}) {
+			knownTruth = append(knownTruth, plane)
+			truthIndices = append(truthIndices, i)
+		}
+	}
+	recovery := ComparePlanes(compared, knownTruth)
+	for i := range recovery.Matches {
+		recovery.Matches[i].Computed = indices[recovery.Matches[i].Computed]
+		recovery.Matches[i].Truth = truthIndices[recovery.Matches[i].Truth]
+	}
+	return recovery
+}
@@ -0,0 +1,56 @@
+package evaluation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/geometry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlaneAngle(t *testing.T) {
+	assert.InDelta(t, 0, PlaneAngle(geometry.Vector{X: 0, Y: 0, Z: 2}, geometry.Vector{X: 0, Y: 0, Z: -1}), delta, "The orientation of the normals doesn't matter")
+	assert.InDelta(t, 90, PlaneAngle(geometry.Vector{X: 1, Y: 0, Z: 0}, geometry.Vector{X: 0, Y: 3, Z: 0}), delta)
+	assert.InDelta(t, 45, PlaneAngle(geometry.Vector{X: 1, Y: 0, Z: 0}, geometry.Vector{X: 1, Y: 1, Z: 0}), delta)
+}
+
+func TestComparePlanes(t *testing.T) {
+	truth := []geometry.Vector{{X: 1, Y: 0, Z: 0}, {X: 0, Y: 1, Z: 0}, {X: 0, Y: 0, Z: 1}}
+	tilt := math.Tan(10 * math.Pi / 180)
+	computed := []geometry.Vector{{X: 0, Y: 0, Z: -1}, {X: 1, Y: tilt, Z: 0}}
+
+	recovery := ComparePlanes(computed, truth)
+	assert.Equal(t, 2, len(recovery.Matches))
+	assert.Equal(t, 0, recovery.Matches[0].Truth)
+	assert.Equal(t, 1, recovery.Matches[0].Computed)
+	assert.InDelta(t, 10, recovery.Matches[0].Angle, delta)
+	assert.Equal(t, PlaneMatch{Truth: 2, Computed: 0, Angle: 0}, recovery.Matches[1])
+	assert.InDelta(t, 5, recovery.MeanAngle, delta)
+	assert.InDelta(t, 10, recovery.MaxAngle, delta)
+	assert.Equal(t, 1, recovery.UnmatchedTruth)
+	assert.Equal(t, 0, recovery.UnmatchedComputed)
+
+	recovery = ComparePlanes(append(computed, truth...), truth)
+	assert.Equal(t, 0.0, recovery.MaxAngle, "Every true plane should be matched with itself")
+	assert.Equal(t, 2, recovery.UnmatchedComputed)
+
+	recovery = ComparePlanes([]geometry.Vector{}, truth)
+	assert.Equal(t, PlaneRecovery{Matches: []PlaneMatch{}, UnmatchedTruth: 3}, recovery)
+}
+
+func TestCompareClusterPlanes(t *testing.T) {
+	truth := []geometry.Vector{{X: 1, Y: 0, Z: 0}, {X: 0, Y: 0, Z: 1}}
+	computed := []geometry.Vector{{X: 1, Y: 0, Z: 0}, {X: 0, Y: 1, Z: 0}, {X: 0, Y: 0, Z: 1}}
+
+	recovery := CompareClusterPlanes(computed, []int{2, 10, 3}, truth)
+	assert.Equal(t, []PlaneMatch{{Truth: 0, Computed: 1, Angle: 90}, {Truth: 1, Computed: 2, Angle: 0}}, recovery.Matches,
+		"The plane of 2 points shouldn't be matched even if it's the same as a true plane")
+	assert.Equal(t, 0, recovery.UnmatchedComputed)
+
+	recovery = CompareClusterPlanes(computed, []int{3, 3, 3}, []geometry.Vector{{}, {X: 0, Y: 0, Z: 1}})
+	assert.Equal(t, []PlaneMatch{{Truth: 1, Computed: 2, Angle: 0}}, recovery.Matches, "Unknown true planes shouldn't be compared")
+	assert.Equal(t, 0, recovery.UnmatchedTruth)
+	assert.Equal(t, 2, recovery.UnmatchedComputed)
+
+	assert.Panics(t, func() { CompareClusterPlanes(computed, []int{3}, truth) })
+}
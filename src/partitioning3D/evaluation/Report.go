@@ -0,0 +1,24 @@
+package evaluation
+
+import (
+	"fmt"
+	"io"
+)
+
+// Writes all metrics of the evaluation in a human readable form to the given writer
+func (eval *Evaluation) Report(w io.Writer) {
+	fmt.Fprintf(w, "\tnumber of planes error: %f%%\n\taccuracy: %f%%\n\tfalse positives: %f%%\n\tfalse negatives: %f%%\n",
+		eval.NumOfPlanesError*100, eval.Accuracy*100, float64(eval.FalsePositives)/float64(eval.TotalEdges)*100,
+		float64(eval.FalseNegatives)/float64(eval.TotalEdges)*100)
+	if eval.NoisePoints > 0 || eval.Outliers > 0 {
+		fmt.Fprintf(w, "\tnoise points: %d\n\tnoise precision: %f%%\n\tnoise recall: %f%%\n",
+			eval.NoisePoints, eval.NoisePrecision()*100, eval.NoiseRecall()*100)
+	}
+	fmt.Fprintf(w, "\tadjusted rand index: %f\n\tvariation of information: %f (split: %f, merge: %f)\n\tnormalized mutual information: %f\n",
+		eval.Metrics.AdjustedRandIndex, eval.Metrics.VI, eval.Metrics.VISplit, eval.Metrics.VIMerge, eval.Metrics.NMI)
+	fmt.Fprintf(w, "\tmean angle error: %f°\n\tmax angle error: %f°\n\tunmatched planes: %d true, %d computed\n", eval.PlaneRecovery.MeanAngle,
+		eval.PlaneRecovery.MaxAngle, eval.PlaneRecovery.UnmatchedTruth, eval.PlaneRecovery.UnmatchedComputed)
+	for _, cluster := range eval.Metrics.Clusters {
+		fmt.Fprintf(w, "\tplane %d (%d points): precision: %f%%, recall: %f%%\n", cluster.Truth, cluster.Size, cluster.Precision*100, cluster.Recall*100)
+	}
+}
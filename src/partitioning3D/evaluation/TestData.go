@@ -1,14 +1,48 @@
 package evaluation
 
 import (
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/algorithm"
 	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/geometry"
 )
 
 // This struct holds test data which can be used to evaluate an algorithm.
 // numberOfPlanes describes how many planes are in the test data, planes is
 // an array of the planes and points is an array of the sampled points.
+// If the planes don't go through the origin, offsets contains the offset of
+// every plane (see geometry.AffinePlane), otherwise it's nil.
+// labels contains the ground truth label of every point, which is the index of
+// its plane or the noise label for the numOfOutliers outliers.
 type TestData struct {
-	NumOfPlanes int
-	Planes      []geometry.Vector
-	Points      []geometry.Vector
+	NumOfPlanes   int
+	Planes        []geometry.Vector
+	Offsets       []float64
+	Points        []geometry.Vector
+	Labels        []int
+	NumOfOutliers int
+}
+
+// Returns the ground truth label of the point at the given index. If the test data has
+// no labels (e.g. because it was stored before they were introduced), the points are
+// assumed to be ordered by their plane with the same number of points for every plane
+// followed by the outliers.
+func (testData *TestData) Label(i int) int {
+	if testData.Labels != nil {
+		return testData.Labels[i]
+	}
+	inliers := len(testData.Points) - testData.NumOfOutliers
+	if i >= inliers {
+		return algorithm.NoiseLabel
+	}
+	return i / (inliers / len(testData.Planes))
+}
+
+// Returns the number of points of every plane
+func (testData *TestData) PlaneSizes() []int {
+	sizes := make([]int, len(testData.Planes))
+	for i := range testData.Points {
+		if label := testData.Label(i); label != algorithm.NoiseLabel {
+			sizes[label]++
+		}
+	}
+	return sizes
 }
@@ -1,46 +1,289 @@
 package evaluation
 
 import (
+	"math"
+	"math/rand"
+
+	alg "github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/algorithm"
 	g "github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/geometry"
 	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/utils"
 )
 
+// Generate test data with the given number of points for every plane, every point is
+// sampled from its plane by the sampling function
 func GenerateData(numOfPlanes, pointsPerPlane int, sampling func(g.Vector) g.Vector) TestData {
-	var planes []g.Vector
-	var points []g.Vector
+	return GenerateDataWithSource(utils.GlobalRandom, numOfPlanes, pointsPerPlane, sampling)
+}
 
-	for i := 0; i < numOfPlanes; i++ {
-		plane := g.CreateRandomUnitVec()
-		planes = append(planes, plane)
-		for j := 0; j < pointsPerPlane; j++ {
-			points = append(points, sampling(plane))
+// Same as GenerateData but the planes are drawn from the given random source
+func GenerateDataWithSource(random *rand.Rand, numOfPlanes, pointsPerPlane int, sampling func(g.Vector) g.Vector) TestData {
+	return GenerateDataWithSizesWithSource(random, EqualPlaneSizes(numOfPlanes, pointsPerPlane), sampling)
+}
+
+// Generate test data with one random plane for every size, from which this many points are
+// sampled by the sampling function
+func GenerateDataWithSizes(sizes []int, sampling func(g.Vector) g.Vector) TestData {
+	return GenerateDataWithSizesWithSource(utils.GlobalRandom, sizes, sampling)
+}
+
+// Same as GenerateDataWithSizes but the planes are drawn from the given random source
+func GenerateDataWithSizesWithSource(random *rand.Rand, sizes []int, sampling func(g.Vector) g.Vector) TestData {
+	planes := make([]g.Vector, 0, len(sizes))
+	for range sizes {
+		planes = append(planes, g.CreateRandomUnitVecWithSource(random))
+	}
+	return generateDataFromPlanes(planes, sizes, sampling)
+}
+
+// Samples as many points from every plane as the size at the same index specifies
+func generateDataFromPlanes(planes []g.Vector, sizes []int, sampling func(g.Vector) g.Vector) TestData {
+	return generateDataFromIndexedPlanes(planes, sizes, func(i int) g.Vector { return sampling(planes[i]) })
+}
+
+// Same as generateDataFromPlanes but the sampling function gets the index of the plane
+func generateDataFromIndexedPlanes(planes []g.Vector, sizes []int, sampling func(int) g.Vector) TestData {
+	points := make([]g.Vector, 0, utils.Sum(sizes))
+	labels := make([]int, 0, utils.Sum(sizes))
+	for i := range planes {
+		for j := 0; j < sizes[i]; j++ {
+			points = append(points, sampling(i))
+			labels = append(labels, i)
 		}
 	}
-	return TestData{NumOfPlanes: numOfPlanes, Planes: planes, Points: points}
+	return TestData{NumOfPlanes: len(planes), Planes: planes, Points: points, Labels: labels}
+}
+
+// Returns the sizes for the given number of planes which all have the same number of points
+func EqualPlaneSizes(numOfPlanes, pointsPerPlane int) []int {
+	sizes := make([]int, numOfPlanes)
+	for i := range sizes {
+		sizes[i] = pointsPerPlane
+	}
+	return sizes
+}
+
+// Returns random sizes for the given number of planes which sum up to the total number of
+// points. Every plane gets at least minPoints points, the remaining points are assigned to
+// the planes with the given weights, so a point is assigned to a plane with a probability
+// that is proportional to its weight. If weights is nil, all planes have the same weight.
+func RandomPlaneSizes(numOfPlanes, totalPoints, minPoints int, weights []float64) []int {
+	return RandomPlaneSizesWithSource(utils.GlobalRandom, numOfPlanes, totalPoints, minPoints, weights)
+}
+
+// Same as RandomPlaneSizes but the sizes are drawn from the given random source
+func RandomPlaneSizesWithSource(random *rand.Rand, numOfPlanes, totalPoints, minPoints int, weights []float64) []int {
+	if numOfPlanes*minPoints > totalPoints {
+		panic("There are not enough points to give every plane the minimum number of points")
+	}
+	if weights == nil {
+		weights = make([]float64, numOfPlanes)
+		for i := range weights {
+			weights[i] = 1
+		}
+	} else if len(weights) != numOfPlanes {
+		panic("There must be one weight for every plane")
+	}
+	totalWeight := utils.Sum(weights)
+
+	sizes := EqualPlaneSizes(numOfPlanes, minPoints)
+	for i := numOfPlanes * minPoints; i < totalPoints; i++ {
+		r := utils.RandomFloatWithSource(random, 0, totalWeight)
+		plane := 0
+		for ; plane < numOfPlanes-1 && r >= weights[plane]; plane++ {
+			r -= weights[plane]
+		}
+		sizes[plane]++
+	}
+	return sizes
+}
+
+// Shuffles the points of the test data together with their labels
+func ShufflePoints(testData *TestData) {
+	ShufflePointsWithSource(utils.GlobalRandom, testData)
+}
+
+// Same as ShufflePoints but the order is drawn from the given random source
+func ShufflePointsWithSource(random *rand.Rand, testData *TestData) {
+	if testData.Labels == nil {
+		testData.Labels = make([]int, len(testData.Points))
+		for i := range testData.Labels {
+			testData.Labels[i] = testData.Label(i)
+		}
+	}
+	random.Shuffle(len(testData.Points), func(i, j int) {
+		testData.Points[i], testData.Points[j] = testData.Points[j], testData.Points[i]
+		testData.Labels[i], testData.Labels[j] = testData.Labels[j], testData.Labels[i]
+	})
 }
 
 // Generate test data without noise, thus every point will be on one plane
 func GenerateDataWithoutNoise(numOfPlanes, pointsPerPlane int) TestData {
-	return GenerateData(numOfPlanes, pointsPerPlane, g.SamplePointFromPlane)
+	return GenerateDataWithoutNoiseWithSource(utils.GlobalRandom, numOfPlanes, pointsPerPlane)
+}
+
+// Same as GenerateDataWithoutNoise but the data is drawn from the given random source
+func GenerateDataWithoutNoiseWithSource(random *rand.Rand, numOfPlanes, pointsPerPlane int) TestData {
+	sampling := func(plane g.Vector) g.Vector {
+		return g.SamplePointFromPlaneWithSource(random, plane)
+	}
+	return GenerateDataWithSource(random, numOfPlanes, pointsPerPlane, sampling)
 }
 
 // Generate test data with gaussian noise, every point will have a distance to it's
 // plane which is sampled out of a normal distribution
 func GenerateDataWithNoise(numOfPlanes, pointsPerPlane int, noise utils.NormalDist) TestData {
+	return GenerateDataWithNoiseWithSource(utils.GlobalRandom, numOfPlanes, pointsPerPlane, noise)
+}
+
+// Same as GenerateDataWithNoise but the data is drawn from the given random source
+func GenerateDataWithNoiseWithSource(random *rand.Rand, numOfPlanes, pointsPerPlane int, noise utils.NormalDist) TestData {
 	sampling := func(plane g.Vector) g.Vector {
-		return g.SamplePointFromPlaneWithNoise(plane, noise)
+		return g.SamplePointFromPlaneWithNoiseWithSource(random, plane, noise)
 	}
-	return GenerateData(numOfPlanes, pointsPerPlane, sampling)
+	return GenerateDataWithSource(random, numOfPlanes, pointsPerPlane, sampling)
 }
 
 // Samples the specified number of points from each of the given planes with noise and
 // returns everything as a test data struct
 func GenerateDataFromPlanesWithNoise(planes []g.Vector, pointsPerPlane int, noise utils.NormalDist) TestData {
-	points := make([]g.Vector, 0, len(planes)*pointsPerPlane)
-	for _, plane := range planes {
+	return GenerateDataFromPlanesWithNoiseWithSource(utils.GlobalRandom, planes, pointsPerPlane, noise)
+}
+
+// Same as GenerateDataFromPlanesWithNoise but the points are drawn from the given random source
+func GenerateDataFromPlanesWithNoiseWithSource(random *rand.Rand, planes []g.Vector, pointsPerPlane int, noise utils.NormalDist) TestData {
+	sampling := func(plane g.Vector) g.Vector {
+		return g.SamplePointFromPlaneWithNoiseWithSource(random, plane, noise)
+	}
+	return generateDataFromPlanes(planes, EqualPlaneSizes(len(planes), pointsPerPlane), sampling)
+}
+
+// Generate test data with gaussian noise where the plane at index i has sizes[i] points
+func GenerateDataWithNoiseAndSizes(sizes []int, noise utils.NormalDist) TestData {
+	return GenerateDataWithNoiseAndSizesWithSource(utils.GlobalRandom, sizes, noise)
+}
+
+// Same as GenerateDataWithNoiseAndSizes but the data is drawn from the given random source
+func GenerateDataWithNoiseAndSizesWithSource(random *rand.Rand, sizes []int, noise utils.NormalDist) TestData {
+	sampling := func(plane g.Vector) g.Vector {
+		return g.SamplePointFromPlaneWithNoiseWithSource(random, plane, noise)
+	}
+	return GenerateDataWithSizesWithSource(random, sizes, sampling)
+}
+
+// Generate test data with gaussian noise from affine planes, which have an offset between
+// -maxOffset and maxOffset. Every point will have a distance to it's plane which is sampled
+// out of a normal distribution.
+func GenerateAffineDataWithNoise(numOfPlanes, pointsPerPlane int, maxOffset float64, noise utils.NormalDist) TestData {
+	return GenerateAffineDataWithNoiseWithSource(utils.GlobalRandom, numOfPlanes, pointsPerPlane, maxOffset, noise)
+}
+
+// Same as GenerateAffineDataWithNoise but the data is drawn from the given random source
+func GenerateAffineDataWithNoiseWithSource(random *rand.Rand, numOfPlanes, pointsPerPlane int, maxOffset float64, noise utils.NormalDist) TestData {
+	planes := make([]g.Vector, 0, numOfPlanes)
+	offsets := make([]float64, 0, numOfPlanes)
+	points := make([]g.Vector, 0, numOfPlanes*pointsPerPlane)
+	labels := make([]int, 0, numOfPlanes*pointsPerPlane)
+
+	for i := 0; i < numOfPlanes; i++ {
+		plane := g.CreateRandomAffinePlaneWithSource(random, maxOffset)
+		planes = append(planes, plane.Normal)
+		offsets = append(offsets, plane.Offset)
 		for j := 0; j < pointsPerPlane; j++ {
-			points = append(points, g.SamplePointFromPlaneWithNoise(plane, noise))
+			points = append(points, g.SamplePointFromAffinePlaneWithNoiseWithSource(random, plane, noise))
+			labels = append(labels, i)
+		}
+	}
+	return TestData{NumOfPlanes: numOfPlanes, Planes: planes, Offsets: offsets, Points: points, Labels: labels}
+}
+
+// Appends the given number of outliers to the test data, which are sampled uniformly
+// from the cube [-1,1]³. Their ground truth label is the noise label.
+func AddUniformOutliers(testData *TestData, numOfOutliers int) {
+	AddUniformOutliersWithSource(utils.GlobalRandom, testData, numOfOutliers)
+}
+
+// Same as AddUniformOutliers but the outliers are drawn from the given random source
+func AddUniformOutliersWithSource(random *rand.Rand, testData *TestData, numOfOutliers int) {
+	AddOutliersInBoxWithSource(random, testData, numOfOutliers, g.UnitCube)
+}
+
+// Appends the given number of outliers to the test data, which are sampled uniformly
+// from the given bounding box. Their ground truth label is the noise label.
+func AddOutliersInBox(testData *TestData, numOfOutliers int, box g.BoundingBox) {
+	AddOutliersInBoxWithSource(utils.GlobalRandom, testData, numOfOutliers, box)
+}
+
+// Same as AddOutliersInBox but the outliers are drawn from the given random source
+func AddOutliersInBoxWithSource(random *rand.Rand, testData *TestData, numOfOutliers int, box g.BoundingBox) {
+	for i := 0; i < numOfOutliers; i++ {
+		if testData.Labels != nil {
+			testData.Labels = append(testData.Labels, alg.NoiseLabel)
+		}
+		testData.Points = append(testData.Points, g.SamplePointFromBoxWithSource(random, box))
+	}
+	testData.NumOfOutliers += numOfOutliers
+}
+
+// The options for generating test data that resembles real scan data
+type GeneratorOptions struct {
+	Noise           g.NoiseModel
+	OutlierFraction float64       // The fraction of all points that are uniformly distributed outliers, must be in [0,1)
+	OutlierBox      g.BoundingBox // The box in which the outliers are sampled, if it's empty the cube [-1,1]³ is used
+	PatchRadius     float64       // If positive, the points are sampled from a disc with this radius on each plane
+	Random          *rand.Rand    // The random source of the generator, if it's nil the global source of math/rand is used
+}
+
+// Returns the random source of the options
+func (options *GeneratorOptions) random() *rand.Rand {
+	if options.Random == nil {
+		return utils.GlobalRandom
+	}
+	return options.Random
+}
+
+// Generate test data according to the given options where the plane at index i has sizes[i]
+// points. The points of every plane are sampled either from the whole plane in the cube [-1,1]³
+// or from a random patch of the plane and the noise is sampled from the noise model.
+// Afterwards the outliers are added, s.t. they make up the given fraction of all points.
+func GenerateDataWithOptions(sizes []int, options GeneratorOptions) TestData {
+	planes := make([]g.Vector, 0, len(sizes))
+	for range sizes {
+		planes = append(planes, g.CreateRandomUnitVecWithSource(options.random()))
+	}
+	return GenerateDataFromPlanesWithOptions(planes, sizes, options)
+}
+
+// Same as GenerateDataWithOptions but the points are sampled from the given planes
+func GenerateDataFromPlanesWithOptions(planes []g.Vector, sizes []int, options GeneratorOptions) TestData {
+	if len(planes) != len(sizes) {
+		panic("There must be one size for every plane")
+	}
+	if options.OutlierFraction < 0 || options.OutlierFraction >= 1 {
+		panic("The fraction of outliers must be at least 0 and smaller than 1")
+	}
+	random := options.random()
+	sampling := func(i int) g.Vector {
+		return g.SamplePointFromPlaneWithNoiseModelWithSource(random, planes[i], options.Noise)
+	}
+	if options.PatchRadius > 0 {
+		// the patches are created by the index of the plane, s.t. planes with the same normal
+		// get different patches
+		patches := make([]*g.PlanePatch, len(planes))
+		sampling = func(i int) g.Vector {
+			if patches[i] == nil {
+				patch := g.CreateRandomPlanePatchWithSource(random, planes[i], options.PatchRadius)
+				patches[i] = &patch
+			}
+			return g.SamplePointFromPatchWithNoiseModelWithSource(random, *patches[i], options.Noise)
 		}
 	}
-	return TestData{NumOfPlanes: len(planes), Planes: planes, Points: points}
+	testData := generateDataFromIndexedPlanes(planes, sizes, sampling)
+
+	box := options.OutlierBox
+	if box == (g.BoundingBox{}) {
+		box = g.UnitCube
+	}
+	inliers := float64(len(testData.Points))
+	AddOutliersInBoxWithSource(random, &testData, int(math.Round(inliers*options.OutlierFraction/(1-options.OutlierFraction))), box)
+	return testData
 }
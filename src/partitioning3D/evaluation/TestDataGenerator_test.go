@@ -6,8 +6,10 @@ import (
 	"flag"
 	"io/ioutil"
 	"math"
+	"math/rand"
 	"os"
 	"strconv"
+	"sync"
 	"testing"
 
 	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/algorithm"
@@ -75,6 +77,158 @@ func TestGenerateDataWithNoise(t *testing.T) {
 	}
 }
 
+func TestGenerateDataWithSizes(t *testing.T) {
+	sizes := []int{3, 10, 1}
+	data := GenerateDataWithNoiseAndSizes(sizes, utils.NormalDist{Mean: 0, Stddev: 0})
+
+	assert.Equal(t, 3, data.NumOfPlanes)
+	assert.Equal(t, 14, len(data.Points))
+	assert.Equal(t, []int{0, 0, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 2}, data.Labels)
+	assert.Equal(t, sizes, data.PlaneSizes())
+	for i, point := range data.Points {
+		assert.InDelta(t, 0, geometry.DistFromPlane(&data.Planes[data.Label(i)], &point), delta, "Every point should be on the plane of its label")
+	}
+}
+
+func TestRandomPlaneSizes(t *testing.T) {
+	sizes := RandomPlaneSizes(4, 100, 10, nil)
+	assert.Equal(t, 4, len(sizes))
+	assert.Equal(t, 100, utils.Sum(sizes))
+	for _, size := range sizes {
+		assert.GreaterOrEqual(t, size, 10)
+	}
+
+	sizes = RandomPlaneSizes(3, 50, 5, []float64{0, 1, 0})
+	assert.Equal(t, []int{5, 40, 5}, sizes, "Planes with a weight of 0 should only get the minimum number of points")
+
+	assert.Panics(t, func() { RandomPlaneSizes(3, 10, 4, nil) })
+	assert.Panics(t, func() { RandomPlaneSizes(3, 10, 1, []float64{1}) })
+}
+
+func TestShufflePoints(t *testing.T) {
+	data := GenerateDataWithNoiseAndSizes([]int{5, 20}, utils.NormalDist{Mean: 0, Stddev: 0})
+	AddUniformOutliers(&data, 3)
+	ShufflePoints(&data)
+
+	assert.Equal(t, []int{5, 20}, data.PlaneSizes())
+	outliers := 0
+	for i, point := range data.Points {
+		if data.Label(i) == algorithm.NoiseLabel {
+			outliers++
+			continue
+		}
+		assert.InDelta(t, 0, geometry.DistFromPlane(&data.Planes[data.Label(i)], &point), delta, "The labels should be shuffled with the points")
+	}
+	assert.Equal(t, 3, outliers)
+}
+
+func TestAddUniformOutliers(t *testing.T) {
+	data := GenerateDataWithoutNoise(3, 10)
+	AddUniformOutliers(&data, 5)
+
+	assert.Equal(t, 35, len(data.Points))
+	assert.Equal(t, 5, data.NumOfOutliers)
+	for i, point := range data.Points[30:] {
+		assert.Equal(t, algorithm.NoiseLabel, data.Label(30+i), "Outliers should have the noise label")
+		assert.True(t, math.Abs(point.X) <= 1 && math.Abs(point.Y) <= 1 && math.Abs(point.Z) <= 1, "Outliers should be in the cube [-1,1]³")
+	}
+	assert.Equal(t, 0, data.Label(9))
+	assert.Equal(t, 2, data.Label(29))
+}
+
+func TestGenerateDataWithOptions(t *testing.T) {
+	options := GeneratorOptions{
+		Noise:           geometry.NoiseModel{Normal: utils.NormalDist{Mean: 0, Stddev: 0}, Tangential: 0.01},
+		OutlierFraction: 0.2,
+		OutlierBox:      geometry.BoundingBox{Min: geometry.Vector{X: 2, Y: 2, Z: 2}, Max: geometry.Vector{X: 3, Y: 3, Z: 3}},
+		PatchRadius:     0.2,
+	}
+	data := GenerateDataWithOptions(EqualPlaneSizes(4, 10), options)
+
+	assert.Equal(t, 50, len(data.Points))
+	assert.Equal(t, 10, data.NumOfOutliers, "20% of the points should be outliers")
+	for i := 0; i < 4; i++ {
+		points := data.Points[i*10 : (i+1)*10]
+		for _, point := range points {
+			assert.InDelta(t, 0, geometry.DistFromPlane(&data.Planes[i], &point), delta, "Without noise along the normal every point should be on its plane")
+			assert.LessOrEqual(t, geometry.VectorDist(point, points[0]), 0.4+0.1, "The points of a plane should be in one patch")
+		}
+	}
+	for _, point := range data.Points[40:] {
+		assert.True(t, point.X >= 2 && point.Y >= 2 && point.Z >= 2, "The outliers should be in the bounding box")
+	}
+
+	data = GenerateDataWithOptions(EqualPlaneSizes(2, 5), GeneratorOptions{})
+	assert.Equal(t, 10, len(data.Points))
+	assert.Equal(t, 0, data.NumOfOutliers)
+
+	assert.Panics(t, func() { GenerateDataWithOptions(EqualPlaneSizes(2, 5), GeneratorOptions{OutlierFraction: 1}) })
+
+	planes := []geometry.Vector{{X: 1, Y: 0, Z: 0}, {X: 0, Y: 0, Z: 1}}
+	data = GenerateDataFromPlanesWithOptions(planes, []int{2, 3}, GeneratorOptions{})
+	assert.Equal(t, planes, data.Planes)
+	assert.Equal(t, []int{2, 3}, data.PlaneSizes())
+	assert.Panics(t, func() { GenerateDataFromPlanesWithOptions(planes, []int{2}, GeneratorOptions{}) })
+
+	duplicates := []geometry.Vector{{X: 0, Y: 0, Z: 1}, {X: 0, Y: 0, Z: 1}}
+	data = GenerateDataFromPlanesWithOptions(duplicates, []int{5, 5}, GeneratorOptions{PatchRadius: 0.01, Random: rand.New(rand.NewSource(1))})
+	assert.Greater(t, geometry.VectorDist(data.Points[0], data.Points[5]), 0.02, "Planes with the same normal should have different patches")
+
+	options.Noise.DegreesOfFreedom = 3
+	options.Random = rand.New(rand.NewSource(42))
+	data = GenerateDataWithOptions(EqualPlaneSizes(4, 10), options)
+	options.Random = rand.New(rand.NewSource(42))
+	assert.Equal(t, data, GenerateDataWithOptions(EqualPlaneSizes(4, 10), options), "The same seed should give the same data")
+}
+
+func TestGenerateAffineDataWithNoise(t *testing.T) {
+	nPlanes := 4
+	pointsPerPlane := 10
+	data := GenerateAffineDataWithNoise(nPlanes, pointsPerPlane, 5, utils.NormalDist{Mean: 0, Stddev: 0})
+
+	assert.Equal(t, nPlanes, len(data.Offsets), "every plane should have an offset")
+	for i, point := range data.Points {
+		plane := geometry.AffinePlane{Normal: data.Planes[i/pointsPerPlane], Offset: data.Offsets[i/pointsPerPlane]}
+		assert.InDelta(t, 0.0, geometry.DistFromAffinePlane(&plane, &point), delta, "Every point should be on it's corresponding plane")
+	}
+
+	evaluation := EvaluatePartitioning(algorithm.PartitioningArray{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+		2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3}, &data)
+	assert.Equal(t, 1.0, evaluation.Accuracy)
+	assert.Equal(t, nPlanes, len(evaluation.ComputedOffsets), "The offsets of the planes should be computed")
+}
+
+func TestGenerateDataWithSource(t *testing.T) {
+	generate := func(seed int64) TestData {
+		random := rand.New(rand.NewSource(seed))
+		data := GenerateDataWithNoiseAndSizesWithSource(random, RandomPlaneSizesWithSource(random, 3, 30, 5, nil), utils.NormalDist{Mean: 0, Stddev: 0.1})
+		AddUniformOutliersWithSource(random, &data, 5)
+		ShufflePointsWithSource(random, &data)
+		return data
+	}
+
+	// every goroutine has its own random source, so the data doesn't depend on the scheduling
+	results := make([]TestData, 8)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = generate(int64(i % 2))
+		}(i)
+	}
+	wg.Wait()
+	for i, data := range results {
+		assert.Equal(t, generate(int64(i%2)), data, "The same seed should give the same data")
+	}
+	assert.NotEqual(t, results[0], results[1])
+
+	random := rand.New(rand.NewSource(1))
+	affine := GenerateAffineDataWithNoiseWithSource(random, 2, 5, 1, utils.NormalDist{Mean: 0, Stddev: 0.1})
+	random = rand.New(rand.NewSource(1))
+	assert.Equal(t, affine, GenerateAffineDataWithNoiseWithSource(random, 2, 5, 1, utils.NormalDist{Mean: 0, Stddev: 0.1}))
+}
+
 func TestDataFromPlanes(t *testing.T) {
 	planes := []geometry.Vector{{X: 1, Y: 0, Z: 0}, {X: 0, Y: 1, Z: 0}, {X: 0, Y: 0, Z: 1}}
 	testData := GenerateDataFromPlanesWithNoise(planes, 5, utils.NormalDist{Mean: 0, Stddev: 0})
@@ -105,7 +259,7 @@ func TestSaveTestDataToFile(t *testing.T) {
 	if *outputFile == "" {
 		return
 	}
-	testData := GenerateDataWithNoise(*numOfPlanes, *pointsPerPlane, utils.NormalDist{Mean: *mean, Stddev: *stddev})
+	testData := generateTestData()
 
 	file, err := os.Create(*outputFile)
 	defer file.Close()
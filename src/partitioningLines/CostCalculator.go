@@ -0,0 +1,34 @@
+package partitioningLines
+
+import (
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/geometry"
+)
+
+// A struct for calculating costs of points that are sampled from lines through the origin.
+// Points in the xy-plane (z = 0) can be used to cluster lines in 2D.
+type CostCalculator struct {
+	Threshold     float64 // The distance of a point to a line where costs a zero
+	Amplification float64 // A factor that is used in the cost calculation
+}
+
+// This function computes the cost for three points that are in the same partition
+// It fits a line through the origin and the points and calculates costs depending on
+// the maximum distance of one point to the line
+func (calc CostCalculator) TripleCost(v1, v2, v3 *geometry.Vector) float64 {
+	line := FitLine(v1, v2, v3)
+	maxDistance := GetMaxDist(&line, v1, v2, v3)
+
+	return calc.Amplification * (maxDistance - calc.Threshold)
+}
+
+// This function accepts the direction of a line through the origin and an arbitrary
+// number of vectors and computes the maximal distance from a point to the line
+func GetMaxDist(line *geometry.Vector, vectors ...*geometry.Vector) float64 {
+	maxDist := 0.0
+	for _, vector := range vectors {
+		if d := geometry.DistFromLine(line, vector); d > maxDist {
+			maxDist = d
+		}
+	}
+	return maxDist
+}
@@ -0,0 +1,40 @@
+package partitioningLines
+
+import (
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/geometry"
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/utils"
+	"gonum.org/v1/gonum/mat"
+)
+
+// This function takes in an arbitrary number of points and tries to find the best
+// line that goes through the origin that minimizes the sum of squared distances from
+// the points to the line. The direction of the line is returned, it has length 1.
+func FitLine(points ...*geometry.Vector) geometry.Vector {
+	size := len(points)
+	matAList := make([]float64, size*3)
+
+	for i := 0; i < size; i++ {
+		matAList[i*3] = (*points[i]).X
+		matAList[i*3+1] = (*points[i]).Y
+		matAList[i*3+2] = (*points[i]).Z
+	}
+	A := mat.NewDense(size, 3, matAList)
+
+	var M mat.Dense
+	M.Mul(A.T(), A)
+
+	var svd mat.SVD
+	ok := svd.Factorize(&M, mat.SVDFull)
+	if !ok {
+		panic("Failed to factorize")
+	}
+	singularValues := svd.Values(nil)
+
+	var u mat.Dense
+	svd.UTo(&u)
+
+	// the direction with the largest singular value is the one with the most variance
+	i := utils.ArgMin(utils.Map(singularValues, func(value float64) float64 { return -value }))
+
+	return geometry.Vector{X: u.At(0, i), Y: u.At(1, i), Z: u.At(2, i)}
+}
@@ -0,0 +1,48 @@
+package partitioningLines
+
+import (
+	"testing"
+
+	g "github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/geometry"
+	"github.com/stretchr/testify/assert"
+)
+
+const delta = 0.00000001
+
+func TestFitLine(t *testing.T) {
+	line := FitLine(&g.Vector{X: 0, Y: 0, Z: 5}, &g.Vector{X: 0, Y: 0, Z: -3}, &g.Vector{X: 0, Y: 0, Z: 7})
+	assert.InDelta(t, 0, line.X, delta, "All points are on the z-axis")
+	assert.InDelta(t, 0, line.Y, delta, "All points are on the z-axis")
+	assert.InDelta(t, 1, line.Z*line.Z, delta, "All points are on the z-axis")
+
+	p1 := g.Vector{X: 1, Y: 2, Z: 0}
+	p2 := g.Vector{X: -2, Y: -4, Z: 0}
+	p3 := g.Vector{X: 0.5, Y: 1, Z: 0}
+	line = FitLine(&p1, &p2, &p3)
+	assert.InDelta(t, 0, GetMaxDist(&line, &p1, &p2, &p3), delta, "All points are on a line through the origin")
+}
+
+func TestTripleCost(t *testing.T) {
+	calc := CostCalculator{Threshold: 2, Amplification: 4}
+
+	assert.InDelta(t, -8,
+		calc.TripleCost(&g.Vector{X: 1, Y: 1, Z: 1}, &g.Vector{X: -3, Y: -3, Z: -3}, &g.Vector{X: 9, Y: 9, Z: 9}),
+		delta,
+		"The points are all on the fitted line",
+	)
+	assert.InDelta(t, -8,
+		calc.TripleCost(&g.Vector{X: 1, Y: 0, Z: 0}, &g.Vector{X: 0, Y: 0, Z: 0}, &g.Vector{X: 0, Y: 0, Z: 0}),
+		delta,
+		"A single point is always on a line through the origin",
+	)
+	assert.InDelta(t, -4,
+		calc.TripleCost(&g.Vector{X: 2, Y: 0, Z: 0}, &g.Vector{X: 0, Y: 1, Z: 0}, &g.Vector{X: 0, Y: 0, Z: 0}),
+		delta,
+		"The best line is the x-axis, so the second point has a distance of 1",
+	)
+}
+
+func TestParsePoints(t *testing.T) {
+	_, err := ParsePoints("points.txt")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,13 @@
+package partitioningLines
+
+import (
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/geometry"
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/partitioning3D"
+)
+
+// Parses the 2D or 3D points which are stored in the given csv file and returns the data
+// as a pointer to a geometry.Vector slice. 2D points get a z-coordinate of 0. If the
+// parsing fails, an error is returned.
+func ParsePoints(path string) (*[]geometry.Vector, error) {
+	return partitioning3D.Parse2DOr3DPoints(path)
+}
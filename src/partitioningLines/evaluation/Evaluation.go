@@ -0,0 +1,59 @@
+package evaluation
+
+import (
+	"math"
+
+	alg "github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/algorithm"
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/geometry"
+	planeEvaluation "github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/partitioning3D/evaluation"
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/partitioningLines"
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/utils"
+)
+
+// The output after an evaluation of an algorithm on points sampled from lines.
+// A `positive` is an edge in the multicut, so an edge which was cut.
+// Analogously, an edge which was not cut, is a negative.
+type Evaluation struct {
+	NumOfLinesError float64
+	Accuracy        float64
+	TotalEdges      int
+	TruePositives   int
+	TrueNegatives   int
+	FalsePositives  int
+	FalseNegatives  int
+	ComputedLines   []geometry.Vector
+	Metrics         planeEvaluation.ClusteringMetrics
+}
+
+// Evaluates a given algorithm with the given test data
+func EvaluateAlgorithm(algorithm alg.PartitioningAlgorithm[geometry.Vector], costCalc alg.CostCalculator[geometry.Vector], testData *TestData) Evaluation {
+	return EvaluatePartitioning(algorithm(&testData.Points, costCalc), testData)
+}
+
+// Evaluates a partitioning that was computed for the points of the given test data
+func EvaluatePartitioning(part alg.PartitioningArray, testData *TestData) Evaluation {
+	numOfLines := len(utils.ToSet(part))
+	numOfLinesError := math.Abs(float64(numOfLines-testData.NumOfLines)) / float64(testData.NumOfLines)
+	metrics := planeEvaluation.ComputeClusteringMetrics(testData.Labels, part)
+	evaluation := Evaluation{
+		NumOfLinesError: numOfLinesError,
+		Accuracy:        metrics.RandIndex,
+		TotalEdges:      metrics.Edges.Total,
+		TruePositives:   metrics.Edges.TruePositives,
+		TrueNegatives:   metrics.Edges.TrueNegatives,
+		FalsePositives:  metrics.Edges.FalsePositives,
+		FalseNegatives:  metrics.Edges.FalseNegatives,
+		Metrics:         metrics,
+	}
+
+	// compute lines
+	partitioning := make(map[int][]*geometry.Vector, numOfLines)
+	for i, partition := range part {
+		partitioning[partition] = append(partitioning[partition], &testData.Points[i])
+	}
+	evaluation.ComputedLines = make([]geometry.Vector, 0, numOfLines)
+	for _, points := range partitioning {
+		evaluation.ComputedLines = append(evaluation.ComputedLines, partitioningLines.FitLine(points...))
+	}
+	return evaluation
+}
@@ -0,0 +1,96 @@
+package evaluation
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/algorithm"
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/geometry"
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/partitioningLines"
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+const delta = 0.00000001
+
+var threshold, amplification, mean, stddev *float64
+var numOfLines, pointsPerLine, dimension *int
+var algorithm1 *string
+
+func init() {
+	threshold = flag.Float64("threshold", 1.0, "The threshold for the cost calculation")
+	amplification = flag.Float64("amplification", 1.0, "The amplification for the cost calculation")
+	mean = flag.Float64("mean", 0, "The mean for the noise")
+	stddev = flag.Float64("stddev", 1.0, "The standard deviation for the noise")
+
+	numOfLines = flag.Int("numberOfLines", 5, "How many lines should be used to sample data points")
+	pointsPerLine = flag.Int("pointsPerLine", 5, "How many points per line should be sampled")
+	dimension = flag.Int("dimension", 3, "Whether the lines are in 2D or 3D")
+	algorithm1 = flag.String("algorithm1", "", "The algorithm that should be evaluated")
+}
+
+func TestGenerateDataWithNoise(t *testing.T) {
+	testData := GenerateDataWithNoise(3, 10, 2, utils.NormalDist{Mean: 0, Stddev: 0})
+
+	assert.Equal(t, 3, testData.NumOfLines)
+	assert.Equal(t, 30, len(testData.Points))
+	for i, point := range testData.Points {
+		assert.Equal(t, 0.0, point.Z, "2D points should be in the xy-plane")
+		assert.InDelta(t, 0, geometry.DistFromLine(&testData.Lines[i/10], &point), delta, "Every point should be on it's corresponding line")
+	}
+	assert.Panics(t, func() { GenerateDataWithNoise(3, 10, 4, utils.NormalDist{}) })
+
+	for _, dimension := range []int{2, 3} {
+		testData = GenerateDataWithNoiseWithSource(rand.New(rand.NewSource(4)), 3, 10, dimension, utils.NormalDist{Mean: 0, Stddev: 0.1})
+		assert.Equal(t, testData, GenerateDataWithNoiseWithSource(rand.New(rand.NewSource(4)), 3, 10, dimension, utils.NormalDist{Mean: 0, Stddev: 0.1}),
+			"The same seed should give the same data")
+	}
+}
+
+func TestEvaluatePartitioning(t *testing.T) {
+	lines := []geometry.Vector{{X: 1, Y: 0, Z: 0}, {X: 0, Y: 1, Z: 0}}
+	testData := GenerateDataFromLinesWithNoise(lines, 3, utils.NormalDist{Mean: 0, Stddev: 0})
+
+	evaluation := EvaluatePartitioning(algorithm.PartitioningArray{0, 0, 0, 1, 1, 1}, &testData)
+	assert.Equal(t, 0.0, evaluation.NumOfLinesError)
+	assert.Equal(t, 1.0, evaluation.Accuracy)
+	assert.Equal(t, 2, len(evaluation.ComputedLines))
+
+	evaluation = EvaluatePartitioning(algorithm.PartitioningArray{0, 0, 1, 1, 1, 1}, &testData)
+	assert.Equal(t, 0.0, evaluation.NumOfLinesError)
+	assert.InDelta(t, 10.0/15.0, evaluation.Accuracy, delta)
+	assert.Equal(t, 2, evaluation.FalsePositives)
+	assert.Equal(t, 3, evaluation.FalseNegatives)
+	assert.Equal(t, evaluation.Accuracy, evaluation.Metrics.RandIndex)
+
+	testData.Labels = []int{1, 0, 1, 0, 1, 0}
+	evaluation = EvaluatePartitioning(algorithm.PartitioningArray{0, 1, 0, 1, 0, 1}, &testData)
+	assert.Equal(t, 1.0, evaluation.Accuracy, "The ground truth is given by the labels and not by the order of the points")
+}
+
+func TestGreedyMovingOnLines(t *testing.T) {
+	lines := []geometry.Vector{{X: 1, Y: 0, Z: 0}, {X: 0, Y: 1, Z: 0}, {X: 0, Y: 0, Z: 1}}
+	testData := GenerateDataFromLinesWithNoise(lines, 5, utils.NormalDist{Mean: 0, Stddev: 0})
+	calc := partitioningLines.CostCalculator{Threshold: 0.01, Amplification: 1}
+
+	evaluation := EvaluateAlgorithm(algorithm.GreedyMoving[geometry.Vector], calc, &testData)
+	assert.Equal(t, 1.0, evaluation.Accuracy, "The algorithms work for lines as well as for planes")
+}
+
+// Evaluates the algorithm specified by `-algorithm1` on data sampled from lines
+func TestEvalAlgorithm(t *testing.T) {
+	flag.Parse()
+	if *algorithm1 == "" {
+		return
+	}
+	testData := GenerateDataWithNoise(*numOfLines, *pointsPerLine, *dimension, utils.NormalDist{Mean: *mean, Stddev: *stddev})
+	algorithm := algorithm.AlgorithmStringToFunc[geometry.Vector](*algorithm1)
+	eval := EvaluateAlgorithm(algorithm, partitioningLines.CostCalculator{Threshold: *threshold, Amplification: *amplification}, &testData)
+
+	fmt.Printf("%s on %d lines in %dD with %d points per line gave the following results:\n", *algorithm1, *numOfLines, *dimension, *pointsPerLine)
+	fmt.Printf("\tnumber of lines error: %f%%\n\taccuracy: %f%%\n\tfalse positives: %f%%\n\tfalse negatives: %f%%\n",
+		eval.NumOfLinesError*100, eval.Accuracy*100, float64(eval.FalsePositives)/float64(eval.TotalEdges)*100,
+		float64(eval.FalseNegatives)/float64(eval.TotalEdges)*100)
+}
@@ -0,0 +1,16 @@
+package evaluation
+
+import (
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/geometry"
+)
+
+// This struct holds test data which can be used to evaluate an algorithm on points
+// sampled from lines through the origin. NumOfLines describes how many lines are in
+// the test data, Lines is an array of the directions of the lines and Points is an
+// array of the sampled points. Labels contains the index of the line of every point.
+type TestData struct {
+	NumOfLines int
+	Lines      []geometry.Vector
+	Points     []geometry.Vector
+	Labels     []int
+}
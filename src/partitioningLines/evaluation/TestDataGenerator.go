@@ -0,0 +1,53 @@
+package evaluation
+
+import (
+	"math/rand"
+
+	g "github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/geometry"
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/utils"
+)
+
+// Generate test data with gaussian noise from random lines through the origin. The dimension
+// must be 2 or 3, for 2 the lines and points are in the xy-plane. Every point will have a
+// distance to it's line which is sampled out of a normal distribution.
+func GenerateDataWithNoise(numOfLines, pointsPerLine, dimension int, noise utils.NormalDist) TestData {
+	return GenerateDataWithNoiseWithSource(utils.GlobalRandom, numOfLines, pointsPerLine, dimension, noise)
+}
+
+// Same as GenerateDataWithNoise but the data is drawn from the given random source
+func GenerateDataWithNoiseWithSource(random *rand.Rand, numOfLines, pointsPerLine, dimension int, noise utils.NormalDist) TestData {
+	var createDirection func(*rand.Rand) g.Vector
+	switch dimension {
+	case 2:
+		createDirection = g.CreateRandomUnitVec2DWithSource
+	case 3:
+		createDirection = g.CreateRandomUnitVecWithSource
+	default:
+		panic("The dimension must be 2 or 3")
+	}
+
+	lines := make([]g.Vector, 0, numOfLines)
+	for i := 0; i < numOfLines; i++ {
+		lines = append(lines, createDirection(random))
+	}
+	return GenerateDataFromLinesWithNoiseWithSource(random, lines, pointsPerLine, noise)
+}
+
+// Samples the specified number of points from each of the given lines through the origin
+// with noise and returns everything as a test data struct
+func GenerateDataFromLinesWithNoise(lines []g.Vector, pointsPerLine int, noise utils.NormalDist) TestData {
+	return GenerateDataFromLinesWithNoiseWithSource(utils.GlobalRandom, lines, pointsPerLine, noise)
+}
+
+// Same as GenerateDataFromLinesWithNoise but the points are drawn from the given random source
+func GenerateDataFromLinesWithNoiseWithSource(random *rand.Rand, lines []g.Vector, pointsPerLine int, noise utils.NormalDist) TestData {
+	points := make([]g.Vector, 0, len(lines)*pointsPerLine)
+	labels := make([]int, 0, len(lines)*pointsPerLine)
+	for i, line := range lines {
+		for j := 0; j < pointsPerLine; j++ {
+			points = append(points, g.SamplePointFromLineWithNoiseWithSource(random, line, noise))
+			labels = append(labels, i)
+		}
+	}
+	return TestData{NumOfLines: len(lines), Lines: lines, Points: points, Labels: labels}
+}
@@ -0,0 +1,38 @@
+package partitioningSubspaces
+
+import (
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/geometry"
+)
+
+// A struct for calculating costs of points that are sampled from linear subspaces. Three
+// points are always in a common subspace with 3 dimensions, so the dimension must be 1 or 2.
+type CostCalculator struct {
+	Dimension     int     // The dimension of the subspaces
+	Threshold     float64 // The distance of a point to a subspace where costs a zero
+	Amplification float64 // A factor that is used in the cost calculation
+}
+
+// This function computes the cost for three points that are in the same partition
+// It fits a subspace through the points and calculates costs depending on the maximum
+// distance of one point to the subspace. This function panics if the dimension isn't 1 or 2.
+func (calc CostCalculator) TripleCost(v1, v2, v3 *geometry.VectorN) float64 {
+	if calc.Dimension != 1 && calc.Dimension != 2 {
+		panic("The dimension of the subspaces must be 1 or 2")
+	}
+	basis := FitSubspace(calc.Dimension, v1, v2, v3)
+	maxDistance := GetMaxDist(basis, v1, v2, v3)
+
+	return calc.Amplification * (maxDistance - calc.Threshold)
+}
+
+// This function accepts an orthonormal basis of a subspace and an arbitrary number
+// of vectors and computes the maximal distance from a point to the subspace
+func GetMaxDist(basis []geometry.VectorN, vectors ...*geometry.VectorN) float64 {
+	maxDist := 0.0
+	for _, vector := range vectors {
+		if d := geometry.DistFromSubspace(basis, vector); d > maxDist {
+			maxDist = d
+		}
+	}
+	return maxDist
+}
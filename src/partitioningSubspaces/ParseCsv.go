@@ -0,0 +1,79 @@
+package partitioningSubspaces
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/geometry"
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/partitioning3D"
+)
+
+// Parses the points which are stored in the given csv file and returns the data as a pointer
+// to a geometry.VectorN slice. Every column of the csv is a coordinate, so the points have as
+// many dimensions as the csv has columns. The first row may be a head with the names of the
+// columns. If the parsing fails, an error is returned.
+func ParsePoints(path string) (*[]geometry.VectorN, error) {
+	if err := partitioning3D.VerifyPath(path); err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.New("The specified file wasn't found!")
+	}
+	defer file.Close()
+
+	return parseCsv(file)
+}
+
+func parseCsv(reader io.Reader) (*[]geometry.VectorN, error) {
+	csvReader := csv.NewReader(reader)
+
+	row, err := csvReader.Read()
+	if err == io.EOF {
+		return nil, errors.New("The file is empty")
+	} else if err != nil {
+		return nil, err
+	}
+
+	data := []geometry.VectorN{}
+
+	// if the first row can't be converted, it's the head of the csv
+	if vector, err := rowToVector(row); err == nil {
+		data = append(data, vector)
+	}
+
+	for {
+		row, err := csvReader.Read()
+
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		if vector, err := rowToVector(row); err != nil {
+			return nil, err
+		} else {
+			data = append(data, vector)
+		}
+	}
+	return &data, nil
+}
+
+// Tries to convert every cell of the row into a float. If this fails the function returns an error.
+func rowToVector(row []string) (geometry.VectorN, error) {
+	vector := make(geometry.VectorN, len(row))
+	for i, cell := range row {
+		value, err := strconv.ParseFloat(strings.TrimSpace(cell), 64)
+		if err != nil {
+			return nil, errors.New("Couldn't convert a cell in the csv into a float")
+		}
+		vector[i] = value
+	}
+	return vector, nil
+}
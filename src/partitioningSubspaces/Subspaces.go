@@ -0,0 +1,60 @@
+package partitioningSubspaces
+
+import (
+	"sort"
+
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/geometry"
+	"gonum.org/v1/gonum/mat"
+)
+
+// This function takes in an arbitrary number of points with the same dimension and finds
+// the linear subspace with the given dimension that minimizes the sum of squared distances
+// from the points to the subspace. An orthonormal basis of the subspace is returned.
+func FitSubspace(dim int, points ...*geometry.VectorN) []geometry.VectorN {
+	if len(points) == 0 {
+		panic("At least one point is needed to fit a subspace")
+	}
+	ambientDimension := len(*points[0])
+	if dim < 1 || dim > ambientDimension {
+		panic("The dimension of the subspace must be between 1 and the dimension of the points")
+	}
+
+	size := len(points)
+	matAList := make([]float64, 0, size*ambientDimension)
+	for _, point := range points {
+		if len(*point) != ambientDimension {
+			panic("All points must have the same dimension")
+		}
+		matAList = append(matAList, *point...)
+	}
+	A := mat.NewDense(size, ambientDimension, matAList)
+
+	var M mat.Dense
+	M.Mul(A.T(), A)
+
+	var svd mat.SVD
+	ok := svd.Factorize(&M, mat.SVDFull)
+	if !ok {
+		panic("Failed to factorize")
+	}
+	singularValues := svd.Values(nil)
+
+	var u mat.Dense
+	svd.UTo(&u)
+
+	// the subspace is spanned by the singular vectors with the largest singular values
+	order := make([]int, ambientDimension)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return singularValues[order[i]] > singularValues[order[j]] })
+
+	basis := make([]geometry.VectorN, dim)
+	for i := range basis {
+		basis[i] = make(geometry.VectorN, ambientDimension)
+		for j := range basis[i] {
+			basis[i][j] = u.At(j, order[i])
+		}
+	}
+	return basis
+}
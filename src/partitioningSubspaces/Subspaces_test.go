@@ -0,0 +1,79 @@
+package partitioningSubspaces
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/algorithm"
+	g "github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/geometry"
+	"github.com/JlsBssmnn/local-search-algorithm-for-cubic-clustering/src/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+const delta = 0.00000001
+
+func TestFitSubspace(t *testing.T) {
+	p1 := g.VectorN{1, 2, 0, 0, 0}
+	p2 := g.VectorN{-3, 1, 0, 0, 0}
+	p3 := g.VectorN{4, 4, 0, 0, 0}
+	basis := FitSubspace(2, &p1, &p2, &p3)
+	assert.Equal(t, 2, len(basis))
+	assert.InDelta(t, 0, GetMaxDist(basis, &p1, &p2, &p3), delta, "All points are in the plane of the first two coordinates")
+
+	basis = FitSubspace(1, &g.VectorN{0, 0, 5, 0}, &g.VectorN{0, 0, -3, 0}, &g.VectorN{0, 0, 7, 0.001})
+	assert.InDelta(t, 1, basis[0][2]*basis[0][2], 0.0001, "The points are close to the third axis")
+
+	assert.Panics(t, func() { FitSubspace(3, &p1, &g.VectorN{1, 2}) })
+	assert.Panics(t, func() { FitSubspace(6, &p1) })
+}
+
+func TestTripleCost(t *testing.T) {
+	calc := CostCalculator{Dimension: 1, Threshold: 2, Amplification: 4}
+
+	assert.InDelta(t, -8,
+		calc.TripleCost(&g.VectorN{1, 1, 1, 1}, &g.VectorN{-2, -2, -2, -2}, &g.VectorN{3, 3, 3, 3}),
+		delta,
+		"The points are all on the fitted line",
+	)
+	assert.InDelta(t, -4,
+		calc.TripleCost(&g.VectorN{2, 0, 0, 0}, &g.VectorN{0, 1, 0, 0}, &g.VectorN{0, 0, 0, 0}),
+		delta,
+		"The best line is the first axis, so the second point has a distance of 1",
+	)
+
+	calc.Dimension = 3
+	assert.Panics(t, func() { calc.TripleCost(&g.VectorN{1, 0, 0, 0}, &g.VectorN{0, 1, 0, 0}, &g.VectorN{0, 0, 1, 0}) })
+}
+
+func TestGreedyMovingOnSubspaces(t *testing.T) {
+	var points []g.VectorN
+	for i := 0; i < 3; i++ {
+		basis := g.CreateRandomSubspace(6, 2)
+		for j := 0; j < 6; j++ {
+			points = append(points, g.SamplePointFromSubspaceWithNoise(basis, utils.NormalDist{Mean: 0, Stddev: 0}))
+		}
+	}
+	calc := CostCalculator{Dimension: 2, Threshold: 0.01, Amplification: 1}
+	partitioning := algorithm.GreedyMoving[g.VectorN](&points, calc)
+
+	for i := range partitioning {
+		for j := range partitioning {
+			assert.Equal(t, i/6 == j/6, partitioning[i] == partitioning[j], "Points should be partitioned by their subspace")
+		}
+	}
+}
+
+func TestParseCsv(t *testing.T) {
+	points, err := parseCsv(strings.NewReader("a,b,c,d\n1,2,3,4\n5,6,7,8\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, []g.VectorN{{1, 2, 3, 4}, {5, 6, 7, 8}}, *points)
+
+	points, err = parseCsv(strings.NewReader("1,2,3,4,5\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, []g.VectorN{{1, 2, 3, 4, 5}}, *points)
+
+	_, err = parseCsv(strings.NewReader("1,2\n3,x\n"))
+	assert.NotNil(t, err)
+	_, err = parseCsv(strings.NewReader("1,2\n3,4,5\n"))
+	assert.NotNil(t, err, "All rows must have the same number of columns")
+}
@@ -0,0 +1,100 @@
+package utils
+
+import "math"
+
+// Solves the assignment problem for the given cost matrix with the Hungarian algorithm.
+// The matrix may be rectangular, every row is assigned to at most one column and every
+// column to at most one row, s.t. as many rows or columns as possible are assigned and the
+// sum of the costs of the assignment is minimal. Returns the column of every row or -1 if
+// the row isn't assigned. All rows must have the same length.
+func Hungarian(cost [][]float64) []int {
+	rows := len(cost)
+	if rows == 0 {
+		return []int{}
+	}
+	cols := len(cost[0])
+	for _, row := range cost {
+		if len(row) != cols {
+			panic("All rows of the cost matrix must have the same length")
+		}
+	}
+
+	if rows > cols {
+		// the algorithm needs at least as many columns as rows, so the transposed problem is solved
+		transposed := make([][]float64, cols)
+		for j := range transposed {
+			transposed[j] = make([]float64, rows)
+			for i := range cost {
+				transposed[j][i] = cost[i][j]
+			}
+		}
+		assignment := make([]int, rows)
+		for i := range assignment {
+			assignment[i] = -1
+		}
+		for j, i := range Hungarian(transposed) {
+			assignment[i] = j
+		}
+		return assignment
+	}
+
+	// the potentials u of the rows and v of the columns, the rows and columns are 1-indexed and
+	// p[j] is the row which is assigned to column j (0 if none), see e.g. the description on e-maxx.ru
+	u := make([]float64, rows+1)
+	v := make([]float64, cols+1)
+	p := make([]int, cols+1)
+	way := make([]int, cols+1)
+	for i := 1; i <= rows; i++ {
+		p[0] = i
+		j0 := 0
+		minValues := make([]float64, cols+1)
+		used := make([]bool, cols+1)
+		for j := range minValues {
+			minValues[j] = math.Inf(1)
+		}
+		for {
+			used[j0] = true
+			i0 := p[j0]
+			delta := math.Inf(1)
+			j1 := 0
+			for j := 1; j <= cols; j++ {
+				if used[j] {
+					continue
+				}
+				if current := cost[i0-1][j-1] - u[i0] - v[j]; current < minValues[j] {
+					minValues[j] = current
+					way[j] = j0
+				}
+				if minValues[j] < delta {
+					delta = minValues[j]
+					j1 = j
+				}
+			}
+			for j := 0; j <= cols; j++ {
+				if used[j] {
+					u[p[j]] += delta
+					v[j] -= delta
+				} else {
+					minValues[j] -= delta
+				}
+			}
+			j0 = j1
+			if p[j0] == 0 {
+				break
+			}
+		}
+		for j0 != 0 {
+			j1 := way[j0]
+			p[j0] = p[j1]
+			j0 = j1
+		}
+	}
+
+	assignment := make([]int, rows)
+	for j := 1; j <= cols; j++ {
+		if p[j] != 0 {
+			assignment[p[j]-1] = j - 1
+		}
+	}
+	return assignment
+}
@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHungarian(t *testing.T) {
+	cost := [][]float64{
+		{4, 1, 3},
+		{2, 0, 5},
+		{3, 2, 2},
+	}
+	assert.Equal(t, []int{1, 0, 2}, Hungarian(cost))
+
+	// more columns than rows
+	cost = [][]float64{
+		{7, 1, 9, 3},
+		{8, 2, 9, 9},
+	}
+	assert.Equal(t, []int{3, 1}, Hungarian(cost))
+
+	// more rows than columns
+	cost = [][]float64{
+		{5, 1},
+		{1, 5},
+		{3, 3},
+	}
+	assert.Equal(t, []int{1, 0, -1}, Hungarian(cost))
+
+	// maximizing by negating the values
+	cost = [][]float64{
+		{-10, -3},
+		{-9, -1},
+	}
+	assert.Equal(t, []int{1, 0}, Hungarian(cost))
+
+	assert.Equal(t, []int{}, Hungarian([][]float64{}))
+	assert.Panics(t, func() { Hungarian([][]float64{{1, 2}, {1}}) })
+}
@@ -33,8 +33,16 @@ func (list *LinkedList[T]) Add(element T) {
 	list.length++
 }
 
+// Appends the other list to this list in constant time. The nodes of the other list
+// are shared, so the other list shouldn't be changed afterwards.
 func (list *LinkedList[T]) AddList(otherList LinkedList[T]) {
-	list.lastElement.next = otherList.firstElement
+	if otherList.length == 0 {
+		return
+	} else if list.length == 0 {
+		list.firstElement = otherList.firstElement
+	} else {
+		list.lastElement.next = otherList.firstElement
+	}
 	list.lastElement = otherList.lastElement
 	list.length += otherList.length
 }
@@ -116,3 +116,20 @@ func TestAddList(t *testing.T) {
 	assert.Equal(t, 13, i)
 	assert.Equal(t, 13, l1.Length())
 }
+
+func TestAddEmptyList(t *testing.T) {
+	l1 := CreateLinkedList[int]()
+	l1.AddList(*CreateLinkedList[int]())
+	assert.Equal(t, 0, l1.Length())
+	iter := l1.Iterator()
+	assert.False(t, iter.HasNext())
+
+	l1.AddList(*CreateLinkedList(1, 2))
+	assert.Equal(t, 2, l1.Length())
+	assert.Equal(t, 2, l1.Get(-1))
+
+	l1.AddList(*CreateLinkedList[int]())
+	assert.Equal(t, 2, l1.Length())
+	l1.Add(3)
+	assert.Equal(t, 3, l1.Get(2))
+}
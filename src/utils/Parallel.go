@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"runtime"
+	"sync"
+)
+
+// Returns the number of workers that should be used if the given number of workers
+// is not positive. This is the number of goroutines that can run simultaneously.
+func DefaultWorkers(workers int) int {
+	if workers < 1 {
+		return runtime.GOMAXPROCS(0)
+	}
+	return workers
+}
+
+// Splits the range [0, n) into at most `workers` contiguous chunks and calls the given
+// function once for every chunk with the index of the worker and the bounds of the chunk
+// (lower bound inclusive, upper bound exclusive). Each chunk is processed in its own
+// goroutine and the function returns once all chunks are processed. If only one worker
+// is used, the function is called directly without starting a goroutine.
+func ParallelFor(n, workers int, function func(worker, low, high int)) {
+	workers = DefaultWorkers(workers)
+	if workers > n {
+		workers = n
+	}
+	if workers <= 1 {
+		if n > 0 {
+			function(0, 0, n)
+		}
+		return
+	}
+
+	var waitGroup sync.WaitGroup
+	chunkSize := n / workers
+	remainder := n % workers
+	low := 0
+	for worker := 0; worker < workers; worker++ {
+		// the first `remainder` chunks get one additional element
+		high := low + chunkSize
+		if worker < remainder {
+			high++
+		}
+		waitGroup.Add(1)
+		go func(worker, low, high int) {
+			function(worker, low, high)
+			waitGroup.Done()
+		}(worker, low, high)
+		low = high
+	}
+	waitGroup.Wait()
+}
@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParallelFor(t *testing.T) {
+	for _, n := range []int{0, 1, 7, 100} {
+		for _, workers := range []int{1, 3, 8, 200} {
+			visited := make([]int, n)
+			chunks := make([]int, workers)
+			ParallelFor(n, workers, func(worker, low, high int) {
+				chunks[worker]++
+				for i := low; i < high; i++ {
+					visited[i]++
+				}
+			})
+			for i := range visited {
+				assert.Equal(t, 1, visited[i], "Every index must be visited exactly once")
+			}
+			for _, count := range chunks {
+				assert.LessOrEqual(t, count, 1, "A worker must not get more than one chunk")
+			}
+		}
+	}
+}
+
+func TestDefaultWorkers(t *testing.T) {
+	assert.Equal(t, 4, DefaultWorkers(4))
+	assert.Positive(t, DefaultWorkers(0))
+	assert.Positive(t, DefaultWorkers(-1))
+}
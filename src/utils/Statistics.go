@@ -0,0 +1,290 @@
+package utils
+
+import (
+	"math"
+	"sort"
+)
+
+// A summary of a sample of values
+type Summary struct {
+	N                int
+	Mean             float64
+	Stddev           float64 // The sample standard deviation
+	Median           float64
+	Q1, Q3           float64 // The first and the third quartile
+	CILower, CIUpper float64 // The confidence interval of the mean
+	ConfidenceLevel  float64
+}
+
+// Returns the interquartile range of the summary
+func (summary Summary) IQR() float64 {
+	return summary.Q3 - summary.Q1
+}
+
+// The result of a Wilcoxon signed-rank test
+type WilcoxonResult struct {
+	N         int     // The number of pairs with a difference other than 0, the other pairs are ignored
+	WPlus     float64 // The sum of the ranks of the positive differences
+	WMinus    float64 // The sum of the ranks of the negative differences
+	PValue    float64 // The two-sided p-value
+	Exact     bool    // Whether the p-value is exact or the normal approximation
+	ZeroPairs int     // The number of pairs with a difference of 0
+}
+
+// Summarizes the given values, the confidence interval of the mean with the given confidence
+// level (e.g. 0.95) is based on the Student-t distribution. If there are fewer than 2 values
+// the confidence interval only contains the mean.
+func Summarize(values []float64, confidenceLevel float64) Summary {
+	if len(values) == 0 {
+		panic("Can't summarize an empty sample")
+	}
+	if confidenceLevel <= 0 || confidenceLevel >= 1 {
+		panic("The confidence level must be between 0 and 1")
+	}
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+
+	n := float64(len(values))
+	mean := Sum(values) / n
+	variance := 0.0
+	for _, value := range values {
+		variance += (value - mean) * (value - mean)
+	}
+	stddev := 0.0
+	if len(values) > 1 {
+		stddev = math.Sqrt(variance / (n - 1))
+	}
+	halfWidth := 0.0
+	if len(values) > 1 {
+		halfWidth = StudentTQuantile(1-(1-confidenceLevel)/2, n-1) * stddev / math.Sqrt(n)
+	}
+	return Summary{
+		N:               len(values),
+		Mean:            mean,
+		Stddev:          stddev,
+		Median:          sortedQuantile(sorted, 0.5),
+		Q1:              sortedQuantile(sorted, 0.25),
+		Q3:              sortedQuantile(sorted, 0.75),
+		CILower:         mean - halfWidth,
+		CIUpper:         mean + halfWidth,
+		ConfidenceLevel: confidenceLevel,
+	}
+}
+
+// Returns the p-quantile of the values, which is linearly interpolated between the closest ranks
+func Quantile(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		panic("Can't compute the quantile of an empty sample")
+	}
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+	return sortedQuantile(sorted, p)
+}
+
+func sortedQuantile(sorted []float64, p float64) float64 {
+	if p < 0 || p > 1 {
+		panic("The probability of a quantile must be between 0 and 1")
+	}
+	position := p * float64(len(sorted)-1)
+	lower := int(math.Floor(position))
+	if lower == len(sorted)-1 {
+		return sorted[lower]
+	}
+	return sorted[lower] + (position-float64(lower))*(sorted[lower+1]-sorted[lower])
+}
+
+// Returns the value of the cumulative distribution function of the standard normal distribution
+func NormalCDF(x float64) float64 {
+	return 0.5 * math.Erfc(-x/math.Sqrt2)
+}
+
+// Returns the value of the cumulative distribution function of the Student-t distribution with
+// the given degrees of freedom
+func StudentTCDF(x, degreesOfFreedom float64) float64 {
+	if degreesOfFreedom <= 0 {
+		panic("The degrees of freedom of a Student-t distribution must be positive")
+	}
+	tail := 0.5 * regularizedIncompleteBeta(degreesOfFreedom/(degreesOfFreedom+x*x), degreesOfFreedom/2, 0.5)
+	if x > 0 {
+		return 1 - tail
+	}
+	return tail
+}
+
+// Returns the p-quantile of the Student-t distribution with the given degrees of freedom
+func StudentTQuantile(p, degreesOfFreedom float64) float64 {
+	if p <= 0 || p >= 1 {
+		panic("The probability of a quantile must be between 0 and 1")
+	}
+	// bisection, the cdf is monotone
+	lower, upper := -1.0, 1.0
+	for StudentTCDF(lower, degreesOfFreedom) > p {
+		lower *= 2
+	}
+	for StudentTCDF(upper, degreesOfFreedom) < p {
+		upper *= 2
+	}
+	for i := 0; i < 200 && upper-lower > 1e-12*math.Max(1, math.Abs(lower)); i++ {
+		middle := (lower + upper) / 2
+		if StudentTCDF(middle, degreesOfFreedom) < p {
+			lower = middle
+		} else {
+			upper = middle
+		}
+	}
+	return (lower + upper) / 2
+}
+
+// Returns the regularized incomplete beta function I_x(a, b), which is evaluated with the
+// continued fraction of Numerical Recipes (modified Lentz's method)
+func regularizedIncompleteBeta(x, a, b float64) float64 {
+	if x <= 0 {
+		return 0
+	} else if x >= 1 {
+		return 1
+	}
+	lgammaA, _ := math.Lgamma(a)
+	lgammaB, _ := math.Lgamma(b)
+	lgammaAB, _ := math.Lgamma(a + b)
+	front := math.Exp(lgammaAB - lgammaA - lgammaB + a*math.Log(x) + b*math.Log(1-x))
+	// the continued fraction converges fast for x < (a+1)/(a+b+2), otherwise the symmetry is used
+	if x > (a+1)/(a+b+2) {
+		return 1 - front*betaContinuedFraction(1-x, b, a)/b
+	}
+	return front * betaContinuedFraction(x, a, b) / a
+}
+
+func betaContinuedFraction(x, a, b float64) float64 {
+	const tiny = 1e-300
+	c := 1.0
+	d := 1 - (a+b)*x/(a+1)
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	result := d
+	for m := 1.0; m <= 300; m++ {
+		// even step
+		numerator := m * (b - m) * x / ((a + 2*m - 1) * (a + 2*m))
+		d = 1 + numerator*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + numerator/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		result *= d * c
+
+		// odd step
+		numerator = -(a + m) * (a + b + m) * x / ((a + 2*m) * (a + 2*m + 1))
+		d = 1 + numerator*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + numerator/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		result *= delta
+		if math.Abs(delta-1) < 1e-15 {
+			break
+		}
+	}
+	return result
+}
+
+// Performs the two-sided Wilcoxon signed-rank test for the paired samples x and y, which must
+// have the same length. Pairs with a difference of 0 are ignored and tied absolute differences
+// get the average of their ranks. If there are at most 50 pairs and no ties the p-value is
+// exact, otherwise it's the normal approximation with a tie and continuity correction.
+func WilcoxonSignedRank(x, y []float64) WilcoxonResult {
+	if len(x) != len(y) {
+		panic("The paired samples must have the same length")
+	}
+	differences := make([]float64, 0, len(x))
+	for i := range x {
+		if difference := x[i] - y[i]; difference != 0 {
+			differences = append(differences, difference)
+		}
+	}
+	result := WilcoxonResult{N: len(differences), ZeroPairs: len(x) - len(differences), PValue: 1}
+	if result.N == 0 {
+		result.Exact = true
+		return result
+	}
+	sort.Slice(differences, func(i, j int) bool {
+		return math.Abs(differences[i]) < math.Abs(differences[j])
+	})
+
+	// rank the absolute differences, tied differences get the average rank
+	ties := false
+	tieCorrection := 0.0
+	for i := 0; i < len(differences); {
+		j := i
+		for j < len(differences) && math.Abs(differences[j]) == math.Abs(differences[i]) {
+			j++
+		}
+		rank := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			if differences[k] > 0 {
+				result.WPlus += rank
+			} else {
+				result.WMinus += rank
+			}
+		}
+		if t := float64(j - i); t > 1 {
+			ties = true
+			tieCorrection += t*t*t - t
+		}
+		i = j
+	}
+
+	n := float64(result.N)
+	if !ties && result.N <= 50 {
+		result.Exact = true
+		distribution := signedRankDistribution(result.N)
+		w := int(math.Round(result.WPlus))
+		lowerTail, upperTail := 0.0, 0.0
+		for sum, probability := range distribution {
+			if sum <= w {
+				lowerTail += probability
+			}
+			if sum >= w {
+				upperTail += probability
+			}
+		}
+		result.PValue = math.Min(1, 2*math.Min(lowerTail, upperTail))
+		return result
+	}
+
+	mean := n * (n + 1) / 4
+	variance := n*(n+1)*(2*n+1)/24 - tieCorrection/48
+	if variance <= 0 {
+		return result
+	}
+	deviation := math.Max(0, math.Abs(result.WPlus-mean)-0.5)
+	result.PValue = math.Min(1, 2*(1-NormalCDF(deviation/math.Sqrt(variance))))
+	return result
+}
+
+// Returns the probabilities of all sums of the signed-rank statistic for n pairs without ties
+// under the null hypothesis, the sum is the index
+func signedRankDistribution(n int) []float64 {
+	maxSum := n * (n + 1) / 2
+	distribution := make([]float64, maxSum+1)
+	distribution[0] = 1
+	for rank := 1; rank <= n; rank++ {
+		// every rank is positive with probability 1/2
+		for sum := maxSum; sum >= 0; sum-- {
+			distribution[sum] /= 2
+			if sum >= rank {
+				distribution[sum] += distribution[sum-rank] / 2
+			}
+		}
+	}
+	return distribution
+}
@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarize(t *testing.T) {
+	summary := Summarize([]float64{5, 1, 4, 2, 3}, 0.95)
+	assert.Equal(t, 5, summary.N)
+	assert.InDelta(t, 3, summary.Mean, 0.00000001)
+	assert.InDelta(t, 1.58113883, summary.Stddev, 0.00000001)
+	assert.InDelta(t, 3, summary.Median, 0.00000001)
+	assert.InDelta(t, 2, summary.Q1, 0.00000001)
+	assert.InDelta(t, 4, summary.Q3, 0.00000001)
+	assert.InDelta(t, 2, summary.IQR(), 0.00000001)
+	// t(0.975, 4) = 2.776445
+	assert.InDelta(t, 3-2.776445*1.58113883/2.23606798, summary.CILower, 0.00001)
+	assert.InDelta(t, 3+2.776445*1.58113883/2.23606798, summary.CIUpper, 0.00001)
+
+	summary = Summarize([]float64{0.7}, 0.95)
+	assert.Equal(t, 0.7, summary.CILower)
+	assert.Equal(t, 0.7, summary.CIUpper)
+	assert.Panics(t, func() { Summarize([]float64{}, 0.95) })
+	assert.Panics(t, func() { Summarize([]float64{1}, 1) })
+
+	assert.InDelta(t, 1.75, Quantile([]float64{4, 3, 2, 1}, 0.25), 0.00000001)
+	assert.InDelta(t, 2.5, Quantile([]float64{4, 3, 2, 1}, 0.5), 0.00000001)
+	assert.Equal(t, 4.0, Quantile([]float64{4, 3, 2, 1}, 1))
+}
+
+func TestStudentTQuantile(t *testing.T) {
+	assert.InDelta(t, 0.5, StudentTCDF(0, 3), 0.00000001)
+	assert.InDelta(t, 12.7062047, StudentTQuantile(0.975, 1), 0.000001)
+	assert.InDelta(t, 2.2281389, StudentTQuantile(0.975, 10), 0.000001)
+	assert.InDelta(t, 2.0422725, StudentTQuantile(0.975, 30), 0.000001)
+	assert.InDelta(t, -1.9599640, StudentTQuantile(0.025, 1000000), 0.00001, "For many degrees of freedom it's the normal distribution")
+	assert.InDelta(t, 0.975, NormalCDF(1.9599640), 0.0000001)
+}
+
+func TestWilcoxonSignedRank(t *testing.T) {
+	result := WilcoxonSignedRank([]float64{2, 3, 4, 5, 6}, []float64{1, 1, 1, 1, 1})
+	assert.True(t, result.Exact)
+	assert.Equal(t, 15.0, result.WPlus)
+	assert.InDelta(t, 2.0/32.0, result.PValue, 0.00000001)
+
+	// the negative differences have the ranks 1, 3 and 4, so W- = 8 and P(W- <= 8) = 0.0244 for 10 pairs
+	x := []float64{-1, 2, -3, -4, 5, 6, 7, 8, 9, 10, 3}
+	y := make([]float64, len(x))
+	y[10] = 3
+	result = WilcoxonSignedRank(x, y)
+	assert.Equal(t, 10, result.N)
+	assert.Equal(t, 1, result.ZeroPairs)
+	assert.Equal(t, 8.0, result.WMinus)
+	assert.Equal(t, 47.0, result.WPlus)
+	assert.InDelta(t, 0.048828125, result.PValue, 0.00000001)
+	result = WilcoxonSignedRank(y, x)
+	assert.InDelta(t, 0.048828125, result.PValue, 0.00000001, "The test should be symmetric")
+
+	// with ties the normal approximation is used
+	result = WilcoxonSignedRank([]float64{1, 1, 1, 1, 1, 1, 1, 1, -1, 2}, make([]float64, 10))
+	assert.False(t, result.Exact)
+	assert.Equal(t, 5.0, result.WMinus, "The 9 tied differences should get the average rank 5")
+	assert.Less(t, result.PValue, 0.05)
+	assert.Greater(t, result.PValue, 0.0)
+
+	result = WilcoxonSignedRank([]float64{1, 2}, []float64{1, 2})
+	assert.Equal(t, 1.0, result.PValue)
+	assert.Panics(t, func() { WilcoxonSignedRank([]float64{1}, []float64{}) })
+}
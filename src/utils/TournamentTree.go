@@ -0,0 +1,130 @@
+package utils
+
+import "math"
+
+// A tournament tree which stores float values at consecutive positions like a slice and
+// keeps track of the smallest value. Values can be changed and removed, removing a value
+// shifts the positions of all following values by one (like removing an element from a
+// slice). Every operation takes O(log n) time, the minimum is available in O(1).
+//
+// Internally the tree has a fixed number of leaves (slots). Removed slots stay in the tree
+// but are marked as dead, the position of a slot is the number of alive slots before it.
+type TournamentTree struct {
+	leaves int
+	values []float64 // the smallest value in the subtree of a node
+	argmin []int     // the slot of the smallest value in the subtree of a node, -1 if there is none
+	alive  []int     // the number of alive slots in the subtree of a node
+}
+
+// Creates a tournament tree which contains the given values at the respective positions
+func CreateTournamentTree(values []float64) *TournamentTree {
+	leaves := 1
+	for leaves < len(values) {
+		leaves *= 2
+	}
+	tree := TournamentTree{
+		leaves: leaves,
+		values: make([]float64, 2*leaves),
+		argmin: make([]int, 2*leaves),
+		alive:  make([]int, 2*leaves),
+	}
+	for slot := 0; slot < leaves; slot++ {
+		node := leaves + slot
+		if slot < len(values) {
+			tree.values[node] = values[slot]
+			tree.argmin[node] = slot
+			tree.alive[node] = 1
+		} else {
+			tree.values[node] = math.Inf(1)
+			tree.argmin[node] = -1
+		}
+	}
+	for node := leaves - 1; node > 0; node-- {
+		tree.pull(node)
+	}
+	return &tree
+}
+
+// Recomputes the values of the given inner node out of its two children. If both children
+// have the same value, the left one wins s.t. the minimum is always the first occurrence.
+func (tree *TournamentTree) pull(node int) {
+	left, right := 2*node, 2*node+1
+	tree.alive[node] = tree.alive[left] + tree.alive[right]
+	if tree.argmin[right] == -1 || (tree.argmin[left] != -1 && tree.values[left] <= tree.values[right]) {
+		tree.values[node] = tree.values[left]
+		tree.argmin[node] = tree.argmin[left]
+	} else {
+		tree.values[node] = tree.values[right]
+		tree.argmin[node] = tree.argmin[right]
+	}
+}
+
+// Updates all ancestors of the given leaf node
+func (tree *TournamentTree) pullAncestors(node int) {
+	for node /= 2; node > 0; node /= 2 {
+		tree.pull(node)
+	}
+}
+
+// Returns the slot of the value at the given position
+func (tree *TournamentTree) slot(position int) int {
+	if position < 0 || position >= tree.Len() {
+		panic("Position is out of bounds")
+	}
+	node := 1
+	for node < tree.leaves {
+		if left := 2 * node; position < tree.alive[left] {
+			node = left
+		} else {
+			position -= tree.alive[left]
+			node = left + 1
+		}
+	}
+	return node - tree.leaves
+}
+
+// Returns the position of the value in the given slot
+func (tree *TournamentTree) position(slot int) int {
+	position := 0
+	for node := tree.leaves + slot; node > 1; node /= 2 {
+		if node%2 == 1 {
+			position += tree.alive[node-1]
+		}
+	}
+	return position
+}
+
+// Returns the number of values in the tree
+func (tree *TournamentTree) Len() int {
+	return tree.alive[1]
+}
+
+// Returns the value at the given position
+func (tree *TournamentTree) Get(position int) float64 {
+	return tree.values[tree.leaves+tree.slot(position)]
+}
+
+// Sets the value at the given position
+func (tree *TournamentTree) Set(position int, value float64) {
+	node := tree.leaves + tree.slot(position)
+	tree.values[node] = value
+	tree.pullAncestors(node)
+}
+
+// Removes the value at the given position, all values after it move one position to the front
+func (tree *TournamentTree) Delete(position int) {
+	node := tree.leaves + tree.slot(position)
+	tree.values[node] = math.Inf(1)
+	tree.argmin[node] = -1
+	tree.alive[node] = 0
+	tree.pullAncestors(node)
+}
+
+// Returns the smallest value and its position. If there are multiple smallest values, the
+// position of the first one is returned. If the tree is empty, +Inf and -1 are returned.
+func (tree *TournamentTree) Min() (float64, int) {
+	if tree.argmin[1] == -1 {
+		return math.Inf(1), -1
+	}
+	return tree.values[1], tree.position(tree.argmin[1])
+}
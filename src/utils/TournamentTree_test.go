@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTournamentTree(t *testing.T) {
+	values := []float64{4, 2, 7, 2, 9}
+	tree := CreateTournamentTree(values)
+
+	assert.Equal(t, 5, tree.Len())
+	min, position := tree.Min()
+	assert.Equal(t, 2.0, min)
+	assert.Equal(t, 1, position, "The first smallest value should be returned")
+
+	tree.Delete(1)
+	assert.Equal(t, 4, tree.Len())
+	assert.Equal(t, 7.0, tree.Get(1), "Values after the deleted one should move to the front")
+	min, position = tree.Min()
+	assert.Equal(t, 2.0, min)
+	assert.Equal(t, 2, position)
+
+	tree.Set(3, -1)
+	min, position = tree.Min()
+	assert.Equal(t, -1.0, min)
+	assert.Equal(t, 3, position)
+
+	for tree.Len() > 0 {
+		tree.Delete(0)
+	}
+	min, position = tree.Min()
+	assert.Equal(t, math.Inf(1), min)
+	assert.Equal(t, -1, position)
+	assert.Panics(t, func() { tree.Get(0) })
+}
+
+func TestTournamentTreeAgainstSlice(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	values := make([]float64, 50)
+	for i := range values {
+		values[i] = float64(random.Intn(20))
+	}
+	tree := CreateTournamentTree(values)
+
+	for len(values) > 0 {
+		position := random.Intn(len(values))
+		if random.Intn(2) == 0 {
+			values = append(values[:position], values[position+1:]...)
+			tree.Delete(position)
+		} else {
+			values[position] = float64(random.Intn(20))
+			tree.Set(position, values[position])
+		}
+
+		min, argmin := MinAndArgMin(values)
+		treeMin, treeArgmin := tree.Min()
+		assert.Equal(t, len(values), tree.Len())
+		if len(values) > 0 {
+			assert.Equal(t, min, treeMin)
+			assert.Equal(t, argmin, treeArgmin)
+		}
+		for i, value := range values {
+			assert.Equal(t, value, tree.Get(i))
+		}
+	}
+}
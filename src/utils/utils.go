@@ -1,6 +1,9 @@
 package utils
 
 import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
 	"math/rand"
 	"sort"
 
@@ -15,20 +18,98 @@ type Numeric interface {
 	int | int64 | uint | int32 | int16 | int8 | float32 | float64
 }
 
+// A random source which draws from the global source of math/rand. The functions which take
+// a random source give the same results with it as the functions without a random source.
+var GlobalRandom = rand.New(globalSource{})
+
+type globalSource struct{}
+
+func (globalSource) Int63() int64    { return rand.Int63() }
+func (globalSource) Uint64() uint64  { return rand.Uint64() }
+func (globalSource) Seed(seed int64) { rand.Seed(seed) }
+
 // Return a random double in the given range, min is
 // inclusive, max is exclusive
 func RandomFloat(min, max float64) float64 {
-	return min + rand.Float64()*(max-min)
+	return RandomFloatWithSource(GlobalRandom, min, max)
+}
+
+// Same as RandomFloat but the number is drawn from the given random source
+func RandomFloatWithSource(random *rand.Rand, min, max float64) float64 {
+	return min + random.Float64()*(max-min)
 }
 
 // Return a random integer in the given range, min is
 // inclusive, max is exclusive
 func RandomInt(min, max int) int {
-	return rand.Intn(max-min) + min
+	return RandomIntWithSource(GlobalRandom, min, max)
+}
+
+// Same as RandomInt but the number is drawn from the given random source
+func RandomIntWithSource(random *rand.Rand, min, max int) int {
+	return random.Intn(max-min) + min
+}
+
+// Derives a seed from the base seed and the given indices (e.g. of an iteration), s.t. every
+// combination of indices gets its own seed which can be reproduced independently of the others.
+// The seed is the FNV-1a hash of the base seed and the indices as little endian 64 bit integers.
+func DeriveSeed(baseSeed int64, indices ...int) int64 {
+	values := make([]int64, 0, len(indices)+1)
+	values = append(values, baseSeed)
+	for _, index := range indices {
+		values = append(values, int64(index))
+	}
+	hash := fnv.New64a()
+	binary.Write(hash, binary.LittleEndian, values)
+	return int64(hash.Sum64())
 }
 
 func FloatFromNormalDist(noise NormalDist) float64 {
-	return rand.NormFloat64()*noise.Stddev + noise.Mean
+	return FloatFromNormalDistWithSource(GlobalRandom, noise)
+}
+
+// Same as FloatFromNormalDist but the number is drawn from the given random source
+func FloatFromNormalDistWithSource(random *rand.Rand, noise NormalDist) float64 {
+	return random.NormFloat64()*noise.Stddev + noise.Mean
+}
+
+// Returns a sample of a Student-t distribution with the given degrees of freedom that is
+// scaled with the stddev and shifted by the mean of the given distribution. For few degrees of
+// freedom the distribution is heavy-tailed, for infinitely many it's the normal distribution.
+func FloatFromStudentT(noise NormalDist, degreesOfFreedom float64) float64 {
+	return FloatFromStudentTWithSource(GlobalRandom, noise, degreesOfFreedom)
+}
+
+// Same as FloatFromStudentT but the number is drawn from the given random source
+func FloatFromStudentTWithSource(random *rand.Rand, noise NormalDist, degreesOfFreedom float64) float64 {
+	if degreesOfFreedom <= 0 {
+		panic("The degrees of freedom of a Student-t distribution must be positive")
+	}
+	chiSquare := 2 * floatFromGamma(random, degreesOfFreedom/2)
+	return random.NormFloat64()/math.Sqrt(chiSquare/degreesOfFreedom)*noise.Stddev + noise.Mean
+}
+
+// Returns a sample of a gamma distribution with the given shape and a scale of 1. This uses
+// the method of Marsaglia and Tsang.
+func floatFromGamma(random *rand.Rand, shape float64) float64 {
+	if shape < 1 {
+		// boost the shape, see Marsaglia and Tsang
+		return floatFromGamma(random, shape+1) * math.Pow(random.Float64(), 1/shape)
+	}
+	d := shape - 1.0/3.0
+	c := 1 / math.Sqrt(9*d)
+	for {
+		x := random.NormFloat64()
+		v := 1 + c*x
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+		u := random.Float64()
+		if math.Log(u) < 0.5*x*x+d-d*v+d*math.Log(v) {
+			return d * v
+		}
+	}
 }
 
 // Check if an element is in the given slice
@@ -1,6 +1,9 @@
 package utils
 
 import (
+	"encoding/binary"
+	"hash/fnv"
+	"math/rand"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -19,3 +22,48 @@ func TestToSet(t *testing.T) {
 	s2 = []string{"this", "this", "a", "string", "slice", "slice", "string"}
 	assert.Equal(t, 4, len(ToSet(s2)))
 }
+
+func TestFloatFromStudentT(t *testing.T) {
+	n := 200000
+	degreesOfFreedom := 5.0
+	sum, squares, tail := 0.0, 0.0, 0
+	for i := 0; i < n; i++ {
+		x := FloatFromStudentT(NormalDist{Mean: 0, Stddev: 1}, degreesOfFreedom)
+		sum += x
+		squares += x * x
+		if x > 4 || x < -4 {
+			tail++
+		}
+	}
+	assert.InDelta(t, 0, sum/float64(n), 0.02)
+	assert.InDelta(t, degreesOfFreedom/(degreesOfFreedom-2), squares/float64(n), 0.1, "The variance of a Student-t distribution is ν/(ν-2)")
+	assert.Greater(t, tail, 100, "The Student-t distribution should be heavy-tailed")
+
+	assert.InDelta(t, 3, FloatFromStudentT(NormalDist{Mean: 3, Stddev: 0}, 0.5), 0.00000001)
+	assert.Panics(t, func() { FloatFromStudentT(NormalDist{}, 0) })
+}
+
+func TestRandomWithSource(t *testing.T) {
+	r1, r2 := rand.New(rand.NewSource(7)), rand.New(rand.NewSource(7))
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, RandomFloatWithSource(r1, -1, 1), RandomFloatWithSource(r2, -1, 1), "The same seed should give the same numbers")
+		assert.Equal(t, RandomIntWithSource(r1, 0, 10), RandomIntWithSource(r2, 0, 10), "The same seed should give the same numbers")
+		assert.Equal(t, FloatFromStudentTWithSource(r1, NormalDist{Mean: 0, Stddev: 1}, 2), FloatFromStudentTWithSource(r2, NormalDist{Mean: 0, Stddev: 1}, 2))
+	}
+
+	rand.Seed(3)
+	values := []float64{RandomFloat(0, 1), float64(RandomInt(0, 100)), FloatFromNormalDist(NormalDist{Mean: 0, Stddev: 1})}
+	rand.Seed(3)
+	assert.Equal(t, []float64{rand.Float64(), float64(rand.Intn(100)), rand.NormFloat64()}, values, "The functions without a source should use the global source")
+}
+
+func TestDeriveSeed(t *testing.T) {
+	assert.Equal(t, DeriveSeed(42, 1, 2), DeriveSeed(42, 1, 2))
+	assert.NotEqual(t, DeriveSeed(42, 1, 2), DeriveSeed(42, 2, 1), "The order of the indices should matter")
+	assert.NotEqual(t, DeriveSeed(42, 1, 2), DeriveSeed(43, 1, 2))
+	assert.NotEqual(t, DeriveSeed(42, 1), DeriveSeed(42, 1, 0), "The number of indices should matter")
+
+	hash := fnv.New64a()
+	binary.Write(hash, binary.LittleEndian, [3]int64{42, 1, 2})
+	assert.Equal(t, int64(hash.Sum64()), DeriveSeed(42, 1, 2), "The seed should be the hash of the base seed and the indices")
+}